@@ -0,0 +1,42 @@
+package env
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Encodings of tag option 'encoding' for []byte and [N]byte.
+const (
+	encodingRaw       = "raw"
+	encodingBase64    = "base64"
+	encodingBase64URL = "base64url"
+	encodingHex       = "hex"
+)
+
+// decodeBytes decodes the value by the specified encoding, the value is used as raw bytes if encoding is empty.
+// Both padded and unpadded base64 are accepted.
+func decodeBytes(value string, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", encodingRaw:
+		return []byte(value), nil
+	case encodingBase64:
+		return base64.RawStdEncoding.DecodeString(strings.TrimRight(value, "="))
+	case encodingBase64URL:
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	case encodingHex:
+		return hex.DecodeString(value)
+	default:
+		return nil, fmt.Errorf("unknown encoding '%s'", encoding)
+	}
+}
+
+// isEncoding reports whether s is a valid value of tag option 'encoding'.
+func isEncoding(s string) bool {
+	switch s {
+	case encodingRaw, encodingBase64, encodingBase64URL, encodingHex:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+func TestEnv_Load_Bytes(t *testing.T) {
+	type Config struct {
+		Raw       []byte   `env:"RAW"`
+		RawPtr    *[]byte  `env:"RAW_PTR,encoding=raw"`
+		Base64    []byte   `env:"BASE64,encoding=base64"`
+		Base64URL []byte   `env:"BASE64URL,encoding=base64url"`
+		Hex       []byte   `env:"HEX,encoding=hex"`
+		Key       [32]byte `env:"KEY,encoding=hex"`
+		Nonce     [4]byte  `env:"NONCE,encoding=base64"`
+		Numbers   [3]byte  `env:"NUMBERS"`
+		Certs     [][]byte `env:"CERTS,encoding=base64"`
+	}
+
+	envs := map[string]string{
+		"RAW":       "hello world",
+		"RAW_PTR":   "1 2 3",
+		"BASE64":    "aGVsbG8=",
+		"BASE64URL": "-_8",
+		"HEX":       "68656c6c6f",
+		"KEY":       "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"NONCE":     "AQIDBA==",
+		"NUMBERS":   "1 2 3",
+		"CERTS":     "YQ== Yg",
+	}
+
+	cfg := &Config{}
+	err := envtest.NewLoader(envs).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, []byte("hello world"), cfg.Raw)
+	require.Equal(t, []byte("1 2 3"), *cfg.RawPtr)
+	require.Equal(t, []byte("hello"), cfg.Base64)
+	require.Equal(t, []byte{0xfb, 0xff}, cfg.Base64URL)
+	require.Equal(t, []byte("hello"), cfg.Hex)
+	for i := 0; i < 32; i++ {
+		require.Equal(t, byte(i), cfg.Key[i])
+	}
+	require.Equal(t, [4]byte{1, 2, 3, 4}, cfg.Nonce)
+	require.Equal(t, [3]byte{1, 2, 3}, cfg.Numbers)
+	require.Equal(t, [][]byte{[]byte("a"), []byte("b")}, cfg.Certs)
+
+	for key, value := range map[string]string{
+		"BASE64": "!!",
+		"HEX":    "zz",
+		"KEY":    "0001",
+	} {
+		require.NotNil(t, envtest.NewLoader(map[string]string{key: value}).Load(&Config{}), key)
+	}
+
+	type BadTag struct {
+		Value []byte `env:"VALUE,encoding=base32"`
+	}
+	require.NotNil(t, env.New().Load(&BadTag{}))
+}
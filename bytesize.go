@@ -0,0 +1,167 @@
+package env
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ByteSize represents a size in bytes, it can be set by human-friendly quantity
+// such as "512MiB" or "1.5GB".
+type ByteSize uint64
+
+// SI (decimal) and IEC (binary) units of ByteSize.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1 << 10
+	MiB ByteSize = 1 << 20
+	GiB ByteSize = 1 << 30
+	TiB ByteSize = 1 << 40
+	PiB ByteSize = 1 << 50
+	EiB ByteSize = 1 << 60
+)
+
+// byteUnits ordered by size from large to small.
+var byteUnits = []struct {
+	name string
+	size ByteSize
+}{
+	{"EiB", EiB}, {"EB", EB},
+	{"PiB", PiB}, {"PB", PB},
+	{"TiB", TiB}, {"TB", TB},
+	{"GiB", GiB}, {"GB", GB},
+	{"MiB", MiB}, {"MB", MB},
+	{"KiB", KiB}, {"KB", KB},
+	{"B", Byte},
+}
+
+// ParseByteSize parses a byte size string such as "1024", "512MiB", "1.5GB" or "10 kb".
+// The unit is case-insensitive, SI units (KB, MB, ...) are powers of 1000 and
+// IEC units (KiB, MiB, ...) are powers of 1024. Fractions of a byte are truncated.
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i == -1 {
+		i = len(s)
+	}
+	num, unit := s[:i], strings.TrimSpace(s[i:])
+	if num == "" {
+		return 0, fmt.Errorf("invalid byte size '%s'", s)
+	}
+
+	size := Byte
+	if unit != "" {
+		found := false
+		for _, u := range byteUnits {
+			if strings.EqualFold(unit, u.name) {
+				size, found = u.size, true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("invalid byte size '%s': unknown unit '%s'", s, unit)
+		}
+	}
+
+	intPart, fracPart := num, ""
+	if j := strings.IndexByte(num, '.'); j != -1 {
+		intPart, fracPart = num[:j], num[j+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return 0, fmt.Errorf("invalid byte size '%s'", s)
+	}
+
+	var n uint64
+	if intPart != "" {
+		v, err := strconv.ParseUint(intPart, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid byte size '%s': %w", s, strconv.ErrRange)
+		}
+		hi, lo := bits.Mul64(v, uint64(size))
+		if hi != 0 {
+			return 0, fmt.Errorf("invalid byte size '%s': %w", s, strconv.ErrRange)
+		}
+		n = lo
+	}
+	if fracPart != "" {
+		if strings.IndexByte(fracPart, '.') != -1 || len(fracPart) > 18 {
+			return 0, fmt.Errorf("invalid byte size '%s'", s)
+		}
+		v, err := strconv.ParseUint(fracPart, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid byte size '%s'", s)
+		}
+		// v * size / 10^len(fracPart), size <= 2^60 and v < 10^18 so the quotient fits in uint64.
+		hi, lo := bits.Mul64(v, uint64(size))
+		frac, _ := bits.Div64(hi, lo, uint64(math.Pow10(len(fracPart))))
+		var carry uint64
+		if n, carry = bits.Add64(n, frac, 0); carry != 0 {
+			return 0, fmt.Errorf("invalid byte size '%s': %w", s, strconv.ErrRange)
+		}
+	}
+	return ByteSize(n), nil
+}
+
+// String return the byte size with the largest unit that represents it
+// with at most two decimals, e.g. "512MiB", "1.5GB" or "100B".
+func (b ByteSize) String() string {
+	for _, u := range byteUnits {
+		if u.size == Byte || b < u.size {
+			continue
+		}
+		hi, lo := bits.Mul64(uint64(b), 100)
+		if _, rem := bits.Div64(hi, lo, uint64(u.size)); rem != 0 {
+			continue
+		}
+		return strconv.FormatFloat(float64(b)/float64(u.size), 'f', -1, 64) + u.name
+	}
+	return strconv.FormatUint(uint64(b), 10) + "B"
+}
+
+// Set implements Setter.
+func (b *ByteSize) Set(value string) error {
+	v, err := ParseByteSize(value)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
+
+// parseBytes parses a byte size string to an integer of the specified bits.
+func parseBytes(value string, bitSize int, signed bool) (uint64, error) {
+	v, err := ParseByteSize(value)
+	if err != nil {
+		return 0, err
+	}
+	max := uint64(math.MaxUint64) >> uint(64-bitSize)
+	if signed {
+		max >>= 1
+	}
+	if uint64(v) > max {
+		return 0, fmt.Errorf("parsing '%s': %w", value, strconv.ErrRange)
+	}
+	return uint64(v), nil
+}
@@ -0,0 +1,99 @@
+package env_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want env.ByteSize
+	}{
+		{"1024", 1024},
+		{"100B", 100},
+		{"10 kb", 10 * env.KB},
+		{"512MiB", 512 * env.MiB},
+		{"1.5GB", 1500 * env.MB},
+		{"1.5GiB", 1536 * env.MiB},
+		{"0.1KiB", 102},
+		{".5KB", 500},
+		{"15EiB", 15 * env.EiB},
+	}
+	for _, c := range cases {
+		v, err := env.ParseByteSize(c.in)
+		require.Nil(t, err, "%s: %+v", c.in, err)
+		require.Equal(t, c.want, v, c.in)
+	}
+
+	for _, in := range []string{"", "MB", "1.2.3MB", "-1MB", "10XB", "16EiB", "18446744073709551616"} {
+		_, err := env.ParseByteSize(in)
+		require.NotNil(t, err, in)
+	}
+}
+
+func TestByteSize_String(t *testing.T) {
+	require.Equal(t, "0B", env.ByteSize(0).String())
+	require.Equal(t, "100B", env.ByteSize(100).String())
+	require.Equal(t, "1KB", env.KB.String())
+	require.Equal(t, "1KiB", env.KiB.String())
+	require.Equal(t, "512MiB", (512 * env.MiB).String())
+	require.Equal(t, "1.5GB", (1500 * env.MB).String())
+	require.Equal(t, "1.5GiB", (1536 * env.MiB).String())
+	require.Equal(t, "1025B", env.ByteSize(1025).String())
+
+	for _, b := range []env.ByteSize{0, 1, 1025, 1500 * env.MB, 7 * env.TiB, 1<<64 - 1} {
+		v, err := env.ParseByteSize(b.String())
+		require.Nil(t, err, "%+v", err)
+		require.Equal(t, b, v)
+	}
+}
+
+func TestEnv_Load_ByteSize(t *testing.T) {
+	type Config struct {
+		Cache     env.ByteSize   `env:"CACHE"`
+		Upload    int64          `env:"UPLOAD,unit=bytes"`
+		Buffer    *uint32        `env:"BUFFER,unit=bytes,default=4KiB"`
+		Limits    []env.ByteSize `env:"LIMITS"`
+		Small     int8           `env:"SMALL,unit=bytes"`
+		SmallUint uint8          `env:"SMALL_UINT,unit=bytes"`
+	}
+
+	envs := map[string]string{
+		"CACHE":      "512MiB",
+		"UPLOAD":     "1.5GB",
+		"LIMITS":     "1KB 2KiB",
+		"SMALL_UINT": "255B",
+	}
+
+	cfg := &Config{}
+	err := envtest.NewLoader(envs).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, 512*env.MiB, cfg.Cache)
+	require.Equal(t, int64(1500000000), cfg.Upload)
+	require.Equal(t, uint32(4096), *cfg.Buffer)
+	require.Equal(t, []env.ByteSize{env.KB, 2 * env.KiB}, cfg.Limits)
+	require.Equal(t, uint8(255), cfg.SmallUint)
+
+	// overflow
+	envs["SMALL"] = "1KB"
+	err = envtest.NewLoader(envs).Load(&Config{})
+	var pe *env.ParseError
+	require.True(t, errors.As(err, &pe))
+	require.True(t, errors.Is(pe.Err, strconv.ErrRange))
+
+	err = envtest.NewLoader(map[string]string{"SMALL": "127", "SMALL_UINT": "256"}).Load(&Config{})
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, "SMALL_UINT", pe.KeyName)
+
+	type BadTag struct {
+		Size int `env:"SIZE,unit=meters"`
+	}
+	require.NotNil(t, env.New().Load(&BadTag{}))
+}
@@ -0,0 +1,19 @@
+// Command envcfg checks, explains and prints the configuration loaded from environment variables.
+//
+// The config types must be compiled into the command, so this command registers no config
+// by itself. Copy this file into your module and register your config types:
+//
+//	envcfg.Register("myapp", func() interface{} { return new(config.Config) }, env.WithPrefix("MYAPP"))
+//
+// See package github.com/yu31/env/envcfg for the usage.
+package main
+
+import (
+	"os"
+
+	"github.com/yu31/env/envcfg"
+)
+
+func main() {
+	os.Exit(envcfg.Main(os.Args[1:], os.Stdout, os.Stderr))
+}
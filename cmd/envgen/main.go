@@ -0,0 +1,51 @@
+// Command envgen generates reflection-free loaders for struct types with env tags.
+//
+// Add the directive to the package that defines the struct types:
+//
+//	//go:generate go run github.com/yu31/env/cmd/envgen -type Config
+//
+// and run go generate, a LoadEnv(g env.Getter) error method is generated for Config and
+// its nested struct types, see package github.com/yu31/env/internal/envgen for details.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yu31/env/internal/envgen"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names, required")
+	output := flag.String("output", "", "output file name, default <type>_env.go")
+	tagName := flag.String("tag", "env", "struct tag name")
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	names := strings.Split(*typeNames, ",")
+
+	src, err := envgen.Generate(envgen.Config{Dir: dir, Types: names, TagName: *tagName})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		*output = strings.ToLower(names[0]) + "_env.go"
+	}
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
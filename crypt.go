@@ -0,0 +1,124 @@
+package env
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/yu31/env/envcore"
+)
+
+// encryptedPrefix is the prefix of values encrypted by Encrypt.
+const encryptedPrefix = envcore.EncryptedPrefix
+
+// ErrNoDecryptionKey is the Err of DecryptError if an encrypted value is loaded without
+// WithDecryptionKey or WithDecryptionKeyFile.
+var ErrNoDecryptionKey = envcore.ErrNoDecryptionKey
+
+// A DecryptError occurs when an encrypted value cannot be decrypted,
+// it never contains the encrypted value.
+type DecryptError = envcore.DecryptError
+
+// GenerateKey return a random 32 bytes key for AES-256.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ReadKeyFile reads the base64-encoded key from file, the leading and trailing white spaces are ignored.
+func ReadKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key file '%s': %v", path, err)
+	}
+	return key, nil
+}
+
+// IsEncrypted reports whether the value is encrypted by Encrypt.
+func IsEncrypted(value string) bool {
+	return envcore.IsEncrypted(value)
+}
+
+// Encrypt encrypts the plaintext with AES-GCM, the key must be 16, 24 or 32 bytes.
+// The result is in the form "enc:v1:<base64>", and it's decrypted by Load transparently
+// with WithDecryptionKey or WithDecryptionKeyFile.
+func Encrypt(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the value encrypted by Encrypt.
+func Decrypt(key []byte, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return "", errors.New("value is not encrypted")
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(value[len(encryptedPrefix):])
+	if err != nil {
+		return "", errors.New("invalid base64 encoding")
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("encrypted value is too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.New("message authentication failed, the key may be wrong")
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// decrypt return the plaintext if the value is encrypted, otherwise return the value as is.
+func (p *Loader) decrypt(key string, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	secret := p.opts.decryptKey
+	if secret == nil {
+		if p.opts.decryptKeyFile == "" {
+			return "", &DecryptError{KeyName: key, Err: ErrNoDecryptionKey}
+		}
+		var err error
+		if secret, err = ReadKeyFile(p.opts.decryptKeyFile); err != nil {
+			return "", &DecryptError{KeyName: key, Err: err}
+		}
+	}
+
+	plaintext, err := Decrypt(secret, value)
+	if err != nil {
+		return "", &DecryptError{KeyName: key, Err: err}
+	}
+	return plaintext, nil
+}
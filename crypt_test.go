@@ -0,0 +1,153 @@
+package env_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+type CryptConfig struct {
+	Password string `env:"PASSWORD,secret"`
+	Port     int    `env:"PORT"`
+	Host     string `env:"HOST"`
+}
+
+func TestEncrypt(t *testing.T) {
+	key, err := env.GenerateKey()
+	require.Nil(t, err)
+	require.Len(t, key, 32)
+
+	value, err := env.Encrypt(key, "s3cr3t")
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(value, "enc:v1:"))
+	require.True(t, env.IsEncrypted(value))
+
+	plaintext, err := env.Decrypt(key, value)
+	require.Nil(t, err)
+	require.Equal(t, "s3cr3t", plaintext)
+
+	// The nonce is random.
+	other, err := env.Encrypt(key, "s3cr3t")
+	require.Nil(t, err)
+	require.NotEqual(t, value, other)
+
+	_, err = env.Encrypt([]byte("short"), "s3cr3t")
+	require.NotNil(t, err)
+}
+
+func TestLoader_Decrypt(t *testing.T) {
+	key, err := env.GenerateKey()
+	require.Nil(t, err)
+	password, err := env.Encrypt(key, "s3cr3t")
+	require.Nil(t, err)
+	port, err := env.Encrypt(key, "8080")
+	require.Nil(t, err)
+
+	envtest.Set(t, map[string]string{"PASSWORD": password, "PORT": port, "HOST": "localhost"})
+
+	var cfg CryptConfig
+	err = env.New(env.WithDecryptionKey(key)).Load(&cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, CryptConfig{Password: "s3cr3t", Port: 8080, Host: "localhost"}, cfg)
+
+	// Read the key from file.
+	keyFile := filepath.Join(t.TempDir(), "env.key")
+	require.Nil(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600))
+	cfg = CryptConfig{}
+	err = env.New(env.WithDecryptionKeyFile(keyFile)).Load(&cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, "s3cr3t", cfg.Password)
+
+	got, err := env.Get[int]("PORT", env.WithDecryptionKey(key))
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, 8080, got)
+}
+
+func TestLoader_DecryptError(t *testing.T) {
+	key, err := env.GenerateKey()
+	require.Nil(t, err)
+	wrongKey, err := env.GenerateKey()
+	require.Nil(t, err)
+	password, err := env.Encrypt(key, "s3cr3t")
+	require.Nil(t, err)
+	envs := map[string]string{"PASSWORD": password}
+
+	cases := []struct {
+		options []env.Option
+		msg     string
+	}{
+		{nil, "env: decrypting 'PASSWORD': no decryption key is set"},
+		{[]env.Option{env.WithDecryptionKey(wrongKey)}, "env: decrypting 'PASSWORD': message authentication failed, the key may be wrong"},
+		{[]env.Option{env.WithDecryptionKey([]byte("short"))}, "env: decrypting 'PASSWORD': crypto/aes: invalid key size 5"},
+		{[]env.Option{env.WithDecryptionKeyFile(filepath.Join(t.TempDir(), "missing"))}, ""},
+	}
+	for _, c := range cases {
+		var cfg CryptConfig
+		err := envtest.NewLoader(envs, c.options...).Load(&cfg)
+		require.NotNil(t, err)
+
+		var decryptErr *env.DecryptError
+		require.True(t, errors.As(err, &decryptErr))
+		require.Equal(t, "PASSWORD", decryptErr.KeyName)
+		if c.msg != "" {
+			require.Equal(t, c.msg, err.Error())
+		}
+		require.NotContains(t, err.Error(), password[len("enc:v1:"):])
+	}
+
+	// The invalid base64 is reported without the value.
+	envs = map[string]string{"PASSWORD": "enc:v1:not-base64!"}
+	err = envtest.NewLoader(envs, env.WithDecryptionKey(key)).Load(&CryptConfig{})
+	require.NotNil(t, err)
+	require.Equal(t, "env: decrypting 'PASSWORD': invalid base64 encoding", err.Error())
+}
+
+func TestLoader_Decrypt_ParseError(t *testing.T) {
+	key, err := env.GenerateKey()
+	require.Nil(t, err)
+	port, err := env.Encrypt(key, "hunter2")
+	require.Nil(t, err)
+	envs := map[string]string{"PORT": port}
+
+	// Neither the plaintext nor the ciphertext is reported.
+	err = envtest.NewLoader(envs, env.WithDecryptionKey(key)).Load(&CryptConfig{})
+	require.NotNil(t, err)
+	require.Equal(t, "env: assigning 'PORT' to 'CryptConfig.Port': converting '******' to type 'int'. details: invalid syntax", err.Error())
+
+	envtest.Set(t, envs)
+	_, err = env.Get[int]("PORT", env.WithDecryptionKey(key))
+	require.NotNil(t, err)
+	require.NotContains(t, err.Error(), "hunter2")
+	require.NotContains(t, err.Error(), port)
+}
+
+func TestLoader_Decrypt_Empty(t *testing.T) {
+	key, err := env.GenerateKey()
+	require.Nil(t, err)
+	empty, err := env.Encrypt(key, "")
+	require.Nil(t, err)
+	envs := map[string]string{"PASSWORD": empty, "PORT": empty}
+
+	// The value that is empty after decryption is ignored as the key is not set.
+	cfg := CryptConfig{Password: "default", Port: 8080}
+	err = envtest.NewLoader(envs, env.WithDecryptionKey(key), env.WithOverride(true)).Load(&cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, CryptConfig{Password: "default", Port: 8080}, cfg)
+
+	// The empty value is applied with allowempty.
+	err = envtest.NewLoader(envs, env.WithDecryptionKey(key), env.WithOverride(true), env.WithAllowEmpty(true)).Load(&cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, CryptConfig{}, cfg)
+
+	envtest.Set(t, map[string]string{"PORT": empty})
+	got := env.GetOr[int]("PORT", 8080, env.WithDecryptionKey(key))
+	require.Equal(t, 8080, got)
+}
@@ -0,0 +1,31 @@
+package env
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of tag option that decode the raw value into the field as a whole.
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// decodeField decodes the value into the field by the specified format.
+func decodeField(field reflect.Value, value string, format string) error {
+	ptr := reflect.New(field.Type())
+	switch format {
+	case formatJSON:
+		if err := json.Unmarshal([]byte(value), ptr.Interface()); err != nil {
+			return err
+		}
+	case formatYAML:
+		if err := yaml.Unmarshal([]byte(value), ptr.Interface()); err != nil {
+			return err
+		}
+	}
+	field.Set(ptr.Elem())
+	return nil
+}
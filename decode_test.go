@@ -0,0 +1,68 @@
+package env_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+func TestEnv_Load_Decode(t *testing.T) {
+	type Limits struct {
+		Beta   bool  `json:"beta" yaml:"beta"`
+		Limits []int `json:"limits" yaml:"limits"`
+	}
+	type Server struct {
+		Host string `json:"host" yaml:"host"`
+		Port int    `json:"port" yaml:"port"`
+	}
+	type Config struct {
+		FeatureFlags Limits                 `env:"FEATURE_FLAGS,json"`
+		FlagsPtr     *Limits                `env:"FLAGS_PTR,json"`
+		Servers      []Server               `env:"SERVERS,json"`
+		Any          map[string]interface{} `env:"ANY,json"`
+		Raw          json.RawMessage        `env:"RAW,json"`
+		Default      Limits                 `env:"DEFAULT,json,default={\"beta\":true}"`
+		YAML         Limits                 `env:"YAML,yaml"`
+		YAMLServers  []Server               `env:"YAML_SERVERS,yaml"`
+	}
+
+	envs := map[string]string{
+		"FEATURE_FLAGS": `{"beta":true,"limits":[1,2]}`,
+		"FLAGS_PTR":     `{"beta":false,"limits":[3]}`,
+		"SERVERS":       `[{"host":"a","port":1},{"host":"b","port":2}]`,
+		"ANY":           `{"a":1,"b":{"c":"d"}}`,
+		"RAW":           `{"x": 1}`,
+		"YAML":          "beta: true\nlimits: [4, 5]",
+		"YAML_SERVERS":  "- {host: c, port: 3}",
+	}
+
+	cfg := &Config{}
+	err := envtest.NewLoader(envs).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, Limits{Beta: true, Limits: []int{1, 2}}, cfg.FeatureFlags)
+	require.Equal(t, Limits{Beta: false, Limits: []int{3}}, *cfg.FlagsPtr)
+	require.Equal(t, []Server{{Host: "a", Port: 1}, {Host: "b", Port: 2}}, cfg.Servers)
+	require.Equal(t, map[string]interface{}{"a": float64(1), "b": map[string]interface{}{"c": "d"}}, cfg.Any)
+	require.Equal(t, json.RawMessage(`{"x": 1}`), cfg.Raw)
+	require.Equal(t, Limits{Beta: true}, cfg.Default)
+	require.Equal(t, Limits{Beta: true, Limits: []int{4, 5}}, cfg.YAML)
+	require.Equal(t, []Server{{Host: "c", Port: 3}}, cfg.YAMLServers)
+
+	envs["SERVERS"] = `[{"host":"a","port":"x"}]`
+	err = envtest.NewLoader(envs).Load(&Config{})
+	var pe *env.ParseError
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, "SERVERS", pe.KeyName)
+	var je *json.UnmarshalTypeError
+	require.True(t, errors.As(pe.Err, &je))
+
+	type BadTag struct {
+		Value []int `env:"VALUE,json,yaml"`
+	}
+	require.NotNil(t, env.New().Load(&BadTag{}))
+}
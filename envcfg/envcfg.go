@@ -0,0 +1,280 @@
+// Package envcfg implements the envcfg command that checks, explains and prints the configuration
+// of the registered config types, it's built on the public API of env.Loader.
+//
+// Register the config types in a tiny main package and run Main:
+//
+//	func main() {
+//		envcfg.Register("myapp", func() interface{} { return new(config.Config) }, env.WithPrefix("MYAPP"))
+//		os.Exit(envcfg.Main(os.Args[1:], os.Stdout, os.Stderr))
+//	}
+//
+// Usage:
+//
+//	envcfg [-config name] check          check the current environment
+//	envcfg [-config name] explain KEY    explain the key: type, default value and field
+//	envcfg [-config name] print          print the effective config with secrets redacted
+//	envcfg keygen                        generate a base64-encoded key for encrypt
+//	envcfg -key-file file encrypt VALUE  encrypt the value in the form "enc:v1:<base64>"
+package envcfg
+
+import (
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/yu31/env"
+)
+
+// Exit codes of Main.
+const (
+	ExitOK      = 0
+	ExitUsage   = 1 // invalid command-line arguments
+	ExitInvalid = 2 // invalid config type, e.g. invalid struct tags
+	ExitParse   = 3 // the value cannot be converted to the field type
+	ExitUnknown = 4 // the key to explain is not found
+)
+
+// redacted replaces the value of secret fields.
+const redacted = "******"
+
+type config struct {
+	name      string
+	newConfig func() interface{}
+	options   []env.Option
+}
+
+var (
+	mu      sync.Mutex
+	configs = make(map[string]*config)
+)
+
+// Register registers a config type with name, the newConfig return a pointer to new config struct,
+// and the options are used to create the env.Loader. It panics if the name is already registered.
+func Register(name string, newConfig func() interface{}, options ...env.Option) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := configs[name]; ok {
+		panic(fmt.Sprintf("envcfg: config '%s' is already registered", name))
+	}
+	configs[name] = &config{name: name, newConfig: newConfig, options: options}
+}
+
+// Main runs the command with the arguments (without program name) and return the exit code.
+func Main(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("envcfg", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	name := fs.String("config", "", "name of the registered config, can be omitted if only one config is registered")
+	keyFile := fs.String("key-file", "", "file of the base64-encoded key to encrypt value")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage:\n")
+		fmt.Fprintf(stderr, "  envcfg [-config name] check          check the current environment\n")
+		fmt.Fprintf(stderr, "  envcfg [-config name] explain KEY    explain the key: type, default value and field\n")
+		fmt.Fprintf(stderr, "  envcfg [-config name] print          print the effective config with secrets redacted\n")
+		fmt.Fprintf(stderr, "  envcfg keygen                        generate a base64-encoded key for encrypt\n")
+		fmt.Fprintf(stderr, "  envcfg -key-file file encrypt VALUE  encrypt the value in the form \"enc:v1:<base64>\"\n")
+		fmt.Fprintf(stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return ExitUsage
+	}
+
+	cmd, cmdArgs := fs.Arg(0), fs.Args()[1:]
+
+	// the commands that don't need config
+	switch {
+	case cmd == "keygen" && len(cmdArgs) == 0:
+		return keygen(stdout, stderr)
+	case cmd == "encrypt" && len(cmdArgs) == 1 && *keyFile != "":
+		return encrypt(*keyFile, cmdArgs[0], stdout, stderr)
+	case cmd == "keygen" || cmd == "encrypt":
+		fs.Usage()
+		return ExitUsage
+	}
+
+	cfg, err := lookup(*name)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	}
+
+	switch {
+	case cmd == "check" && len(cmdArgs) == 0:
+		return check(cfg, stdout, stderr)
+	case cmd == "explain" && len(cmdArgs) == 1:
+		return explain(cfg, cmdArgs[0], stdout, stderr)
+	case cmd == "print" && len(cmdArgs) == 0:
+		return printConfig(cfg, stdout, stderr)
+	default:
+		fs.Usage()
+		return ExitUsage
+	}
+}
+
+// lookup return the registered config by name, the name can be empty if only one config is registered.
+func lookup(name string) (*config, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if name != "" {
+		cfg, ok := configs[name]
+		if !ok {
+			return nil, fmt.Errorf("envcfg: config '%s' is not registered, registered: %s", name, strings.Join(names(), ", "))
+		}
+		return cfg, nil
+	}
+	switch len(configs) {
+	case 0:
+		return nil, errors.New("envcfg: no config is registered")
+	case 1:
+		for _, cfg := range configs {
+			return cfg, nil
+		}
+	}
+	return nil, fmt.Errorf("envcfg: flag -config is required, registered: %s", strings.Join(names(), ", "))
+}
+
+func names() []string {
+	var list []string
+	for name := range configs {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// load loads the config and return its fields, the error is written to stderr.
+func load(cfg *config, stderr io.Writer) ([]env.Field, int) {
+	l := env.New(cfg.options...)
+	v := cfg.newConfig()
+	if err := l.Load(v); err != nil {
+		fmt.Fprintln(stderr, err)
+		var pe *env.ParseError
+		var de *env.DecryptError
+		var re *env.ResolveError
+		if errors.As(err, &pe) || errors.As(err, &de) || errors.As(err, &re) {
+			return nil, ExitParse
+		}
+		return nil, ExitInvalid
+	}
+	fields, err := l.Fields(v)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return nil, ExitInvalid
+	}
+	return fields, ExitOK
+}
+
+func check(cfg *config, stdout, stderr io.Writer) int {
+	fields, code := load(cfg, stderr)
+	if code != ExitOK {
+		return code
+	}
+	fmt.Fprintf(stdout, "ok: %d keys of config '%s' are valid\n", len(fields), cfg.name)
+	return ExitOK
+}
+
+// explain explains the key from the fields of config, so that it works even if the
+// current environment has invalid values. The value is shown only if the config can be loaded.
+func explain(cfg *config, key string, stdout, stderr io.Writer) int {
+	fields, err := env.New(cfg.options...).Fields(cfg.newConfig())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitInvalid
+	}
+	for _, f := range fields {
+		if !strings.EqualFold(f.Key, key) {
+			continue
+		}
+		fmt.Fprintf(stdout, "Key:     %s\n", strings.ToUpper(f.Key))
+		fmt.Fprintf(stdout, "Field:   %s\n", f.Name)
+		fmt.Fprintf(stdout, "Type:    %s\n", f.Type.String())
+		fmt.Fprintf(stdout, "Default: %s\n", f.Default)
+		if f.Usage != "" {
+			fmt.Fprintf(stdout, "Usage:   %s\n", f.Usage)
+		}
+		fmt.Fprintf(stdout, "Secret:  %t\n", f.Secret)
+
+		loaded, code := load(cfg, stderr)
+		if code != ExitOK {
+			fmt.Fprintf(stdout, "Value:   (unknown, the config cannot be loaded)\n")
+			return ExitOK
+		}
+		for _, lf := range loaded {
+			if lf.Key == f.Key {
+				fmt.Fprintf(stdout, "Value:   %s\n", display(lf))
+			}
+		}
+		return ExitOK
+	}
+	fmt.Fprintf(stderr, "envcfg: key '%s' is not found in config '%s'\n", key, cfg.name)
+	return ExitUnknown
+}
+
+func printConfig(cfg *config, stdout, stderr io.Writer) int {
+	fields, code := load(cfg, stderr)
+	if code != ExitOK {
+		return code
+	}
+	for _, f := range fields {
+		fmt.Fprintf(stdout, "%s=%s\n", strings.ToUpper(f.Key), display(f))
+	}
+	return ExitOK
+}
+
+// display return the value of field to display, the secret is redacted.
+func display(f env.Field) string {
+	if f.Secret && f.Value != "" {
+		return redacted
+	}
+	if f.Value == "" {
+		return zeroValue(f.Type)
+	}
+	return f.Value
+}
+
+// zeroValue return the zero value of the scalar types, e.g. "false", "0" and "0s",
+// since the Field.Value is empty if the value is zero.
+func zeroValue(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(reflect.Zero(t).Interface())
+	}
+	return ""
+}
+
+func keygen(stdout, stderr io.Writer) int {
+	key, err := env.GenerateKey()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	}
+	fmt.Fprintln(stdout, base64.StdEncoding.EncodeToString(key))
+	return ExitOK
+}
+
+func encrypt(keyFile string, value string, stdout, stderr io.Writer) int {
+	key, err := env.ReadKeyFile(keyFile)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	}
+	encrypted, err := env.Encrypt(key, value)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	}
+	fmt.Fprintln(stdout, encrypted)
+	return ExitOK
+}
@@ -0,0 +1,183 @@
+package envcfg_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envcfg"
+	"github.com/yu31/env/envtest"
+)
+
+type Config struct {
+	Port     int    `env:"PORT,default=8080" desc:"port to listen"`
+	Password string `env:"PASSWORD,secret"`
+	Token    string `env:"TOKEN,secret"`
+	Debug    bool   `env:"DEBUG"`
+}
+
+type Secret struct {
+	Pin int `env:"PIN,secret"`
+}
+
+type Invalid struct {
+	Port int `env:"PORT,unit=meters"`
+}
+
+func init() {
+	envcfg.Register("myapp", func() interface{} { return new(Config) }, env.WithPrefix("ENVCFG"))
+	envcfg.Register("invalid", func() interface{} { return new(Invalid) })
+	envcfg.Register("secret", func() interface{} { return new(Secret) }, env.WithPrefix("ENVCFG"))
+}
+
+func run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := envcfg.Main(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// setenv sets the environment variables, and unsets other keys of the registered configs.
+func setenv(t *testing.T, envs map[string]string) {
+	envtest.Unset(t, "ENVCFG_PORT", "ENVCFG_PASSWORD", "ENVCFG_TOKEN", "ENVCFG_DEBUG", "ENVCFG_PIN")
+	envtest.Set(t, envs)
+}
+
+func TestMain_Check(t *testing.T) {
+	setenv(t, map[string]string{"ENVCFG_PASSWORD": "p@ss"})
+
+	code, stdout, _ := run("-config", "myapp", "check")
+	require.Equal(t, envcfg.ExitOK, code)
+	require.Equal(t, "ok: 4 keys of config 'myapp' are valid\n", stdout)
+
+	envtest.Set(t, map[string]string{"ENVCFG_PORT": "http"})
+	code, _, stderr := run("-config", "myapp", "check")
+	require.Equal(t, envcfg.ExitParse, code)
+	require.Contains(t, stderr, "ENVCFG_PORT")
+
+	code, _, _ = run("-config", "invalid", "check")
+	require.Equal(t, envcfg.ExitInvalid, code)
+}
+
+func TestMain_Explain(t *testing.T) {
+	setenv(t, map[string]string{"ENVCFG_PASSWORD": "p@ss"})
+
+	code, stdout, _ := run("-config", "myapp", "explain", "envcfg_port")
+	require.Equal(t, envcfg.ExitOK, code)
+	require.Equal(t, `Key:     ENVCFG_PORT
+Field:   Config.Port
+Type:    int
+Default: 8080
+Usage:   port to listen
+Secret:  false
+Value:   8080
+`, stdout)
+
+	code, stdout, _ = run("-config", "myapp", "explain", "ENVCFG_PASSWORD")
+	require.Equal(t, envcfg.ExitOK, code)
+	require.Contains(t, stdout, "Value:   ******\n")
+	require.NotContains(t, stdout, "p@ss")
+
+	code, _, _ = run("-config", "myapp", "explain", "ENVCFG_NOTFOUND")
+	require.Equal(t, envcfg.ExitUnknown, code)
+}
+
+func TestMain_Print(t *testing.T) {
+	setenv(t, map[string]string{"ENVCFG_PASSWORD": "p@ss"})
+
+	code, stdout, _ := run("-config", "myapp", "print")
+	require.Equal(t, envcfg.ExitOK, code)
+	require.Equal(t, "ENVCFG_PORT=8080\nENVCFG_PASSWORD=******\nENVCFG_TOKEN=\nENVCFG_DEBUG=false\n", stdout)
+}
+
+func TestMain_Print_Zero(t *testing.T) {
+	setenv(t, map[string]string{"ENVCFG_PORT": "0", "ENVCFG_DEBUG": "false"})
+
+	code, stdout, _ := run("-config", "myapp", "print")
+	require.Equal(t, envcfg.ExitOK, code)
+	require.Equal(t, "ENVCFG_PORT=0\nENVCFG_PASSWORD=\nENVCFG_TOKEN=\nENVCFG_DEBUG=false\n", stdout)
+}
+
+func TestMain_Secret(t *testing.T) {
+	setenv(t, map[string]string{"ENVCFG_PIN": "hunter2-topsecret"})
+
+	for _, args := range [][]string{{"check"}, {"print"}, {"explain", "ENVCFG_PIN"}} {
+		_, stdout, stderr := run(append([]string{"-config", "secret"}, args...)...)
+		require.Contains(t, stderr, "ENVCFG_PIN", args)
+		require.NotContains(t, stderr, "hunter2", args)
+		require.NotContains(t, stdout, "hunter2", args)
+	}
+
+	code, _, stderr := run("-config", "secret", "check")
+	require.Equal(t, envcfg.ExitParse, code)
+	require.Equal(t, "env: assigning 'ENVCFG_PIN' to 'Secret.Pin': converting '******' to type 'int'. details: invalid syntax\n", stderr)
+}
+
+func TestMain_Explain_Invalid(t *testing.T) {
+	// The key can be explained even if other key has invalid value.
+	setenv(t, map[string]string{"ENVCFG_PORT": "http"})
+
+	code, stdout, stderr := run("-config", "myapp", "explain", "ENVCFG_TOKEN")
+	require.Equal(t, envcfg.ExitOK, code)
+	require.Equal(t, `Key:     ENVCFG_TOKEN
+Field:   Config.Token
+Type:    string
+Default: 
+Secret:  true
+Value:   (unknown, the config cannot be loaded)
+`, stdout)
+	require.Contains(t, stderr, "ENVCFG_PORT")
+}
+
+func TestMain_Usage(t *testing.T) {
+	code, _, stderr := run("check")
+	require.Equal(t, envcfg.ExitUsage, code)
+	require.Contains(t, stderr, "flag -config is required")
+
+	code, _, _ = run("-config", "notfound", "check")
+	require.Equal(t, envcfg.ExitUsage, code)
+
+	code, _, _ = run("-config", "myapp")
+	require.Equal(t, envcfg.ExitUsage, code)
+
+	code, _, _ = run("-config", "myapp", "explain")
+	require.Equal(t, envcfg.ExitUsage, code)
+
+	require.Panics(t, func() {
+		envcfg.Register("myapp", func() interface{} { return new(Config) })
+	})
+}
+
+func TestMain_Encrypt(t *testing.T) {
+	setenv(t, nil)
+
+	code, stdout, _ := run("keygen")
+	require.Equal(t, envcfg.ExitOK, code)
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(stdout))
+	require.Nil(t, err)
+	require.Len(t, key, 32)
+
+	keyFile := filepath.Join(t.TempDir(), "env.key")
+	require.Nil(t, os.WriteFile(keyFile, []byte(stdout), 0o600))
+
+	code, stdout, _ = run("-key-file", keyFile, "encrypt", "p@ss")
+	require.Equal(t, envcfg.ExitOK, code)
+	encrypted := strings.TrimSpace(stdout)
+	plaintext, err := env.Decrypt(key, encrypted)
+	require.Nil(t, err)
+	require.Equal(t, "p@ss", plaintext)
+
+	// The encrypted value cannot be loaded without key.
+	envtest.Set(t, map[string]string{"ENVCFG_PASSWORD": encrypted})
+	code, _, stderr := run("-config", "myapp", "check")
+	require.Equal(t, envcfg.ExitParse, code)
+	require.Equal(t, "env: decrypting 'ENVCFG_PASSWORD': no decryption key is set\n", stderr)
+
+	code, _, _ = run("encrypt", "p@ss")
+	require.Equal(t, envcfg.ExitUsage, code)
+}
@@ -0,0 +1,90 @@
+// Package envcore provides the types shared by package env and the loaders generated by
+// envgen. It imports only errors, strconv and strings, so that the generated loaders can
+// be used without reflect, net/http and the decoders of package env, e.g. with TinyGo.
+//
+// The types are aliased in package env, use them from there unless the dependencies matter.
+package envcore
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Getter is implemented by types can self-serialize keys.
+type Getter interface {
+	// Merge return a new key with merge prefix and key
+	Merge(prefix string, key string) string
+
+	// Get return (value, found, error) with specified key
+	Get(key string) (string, bool, error)
+}
+
+// A ParseError occurs when an environment variable cannot be converted to
+// the type required by a struct field during assignment.
+// The Value and the details of Err are redacted if the field is tagged with option 'secret'.
+type ParseError struct {
+	KeyName   string
+	FieldName string
+	TypeName  string
+	Value     string
+	Err       error
+}
+
+func (e *ParseError) Error() string {
+	if e.FieldName == "" {
+		return "env: assigning '" + e.KeyName + "': converting '" + e.Value + "' to type '" + e.TypeName + "'. details: " + errString(e.Err)
+	}
+	return "env: assigning '" + e.KeyName + "' to '" + e.FieldName + "': converting '" + e.Value + "' to type '" + e.TypeName + "'. details: " + errString(e.Err)
+}
+
+// Redacted replaces the value of secret field in ParseError.
+const Redacted = "******"
+
+// errRedacted replaces the error of secret field that may contain the value.
+var errRedacted = errors.New("the details are redacted for secret field")
+
+// RedactError return the error without the value of secret field, only the cause of
+// strconv.NumError is kept since it doesn't contain the value.
+func RedactError(err error) error {
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		return ne.Err
+	}
+	return errRedacted
+}
+
+// EncryptedPrefix is the prefix of the values encrypted by env.Encrypt.
+const EncryptedPrefix = "enc:v1:"
+
+// IsEncrypted reports whether the value is encrypted by env.Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, EncryptedPrefix)
+}
+
+// ErrNoDecryptionKey is the Err of DecryptError if an encrypted value is loaded without
+// decryption key.
+var ErrNoDecryptionKey = errors.New("no decryption key is set")
+
+// A DecryptError occurs when an encrypted value cannot be decrypted,
+// it never contains the encrypted value.
+type DecryptError struct {
+	KeyName string
+	Err     error
+}
+
+func (e *DecryptError) Error() string {
+	return "env: decrypting '" + e.KeyName + "': " + errString(e.Err)
+}
+
+func (e *DecryptError) Unwrap() error {
+	return e.Err
+}
+
+// errString return the message of err as fmt does.
+func errString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
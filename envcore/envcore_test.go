@@ -0,0 +1,37 @@
+package envcore_test
+
+import (
+	"errors"
+	"go/build"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env/envcore"
+)
+
+func TestImports(t *testing.T) {
+	// The generated loaders depend on this package only, keep it free of reflect and others.
+	pkg, err := build.ImportDir(".", 0)
+	require.Nil(t, err)
+	require.Equal(t, []string{"errors", "strconv", "strings"}, pkg.Imports)
+}
+
+func TestParseError(t *testing.T) {
+	_, numErr := strconv.Atoi("x")
+	err := &envcore.ParseError{KeyName: "PORT", FieldName: "Config.Port", TypeName: "int", Value: "x", Err: numErr}
+	require.Equal(t, "env: assigning 'PORT' to 'Config.Port': converting 'x' to type 'int'. details: strconv.Atoi: parsing \"x\": invalid syntax", err.Error())
+
+	err = &envcore.ParseError{KeyName: "PORT", TypeName: "int", Value: envcore.Redacted, Err: envcore.RedactError(numErr)}
+	require.Equal(t, "env: assigning 'PORT': converting '******' to type 'int'. details: invalid syntax", err.Error())
+	require.Equal(t, "the details are redacted for secret field", envcore.RedactError(errors.New("x")).Error())
+}
+
+func TestDecryptError(t *testing.T) {
+	err := &envcore.DecryptError{KeyName: "PASSWORD", Err: envcore.ErrNoDecryptionKey}
+	require.Equal(t, "env: decrypting 'PASSWORD': no decryption key is set", err.Error())
+	require.True(t, errors.Is(err, envcore.ErrNoDecryptionKey))
+	require.True(t, envcore.IsEncrypted("enc:v1:AAAA"))
+	require.False(t, envcore.IsEncrypted("plain"))
+}
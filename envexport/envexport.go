@@ -0,0 +1,144 @@
+// Package envexport generates the .env template, shell script, Docker Compose environment block
+// and Kubernetes manifests from the fields of config struct returned by env.Loader.Fields.
+//
+// The value of a field is its current value if it's non-zero, otherwise its default value.
+//
+//	fields, err := env.New(env.WithPrefix("MYAPP")).Fields(&cfg)
+//	if err != nil {
+//		return err
+//	}
+//	err = envexport.WriteDotEnv(os.Stdout, fields)
+package envexport
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/yu31/env"
+)
+
+// WriteDotEnv writes the fields in .env format, the usage is written as comment.
+//
+//	# port to listen
+//	MYAPP_PORT=8080
+func WriteDotEnv(w io.Writer, fields []env.Field) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range fields {
+		writeComment(bw, f)
+		fmt.Fprintf(bw, "%s=%s\n", key(f), quoteDotEnv(value(f)))
+	}
+	return bw.Flush()
+}
+
+// WriteShell writes the fields as shell script that exports the environment variables.
+//
+//	#!/bin/sh
+//	# port to listen
+//	export MYAPP_PORT='8080'
+func WriteShell(w io.Writer, fields []env.Field) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("#!/bin/sh\n")
+	for _, f := range fields {
+		writeComment(bw, f)
+		fmt.Fprintf(bw, "export %s=%s\n", key(f), quoteShell(value(f)))
+	}
+	return bw.Flush()
+}
+
+// WriteCompose writes the fields as the environment block of Docker Compose service.
+//
+//	environment:
+//	  MYAPP_PORT: "8080"
+func WriteCompose(w io.Writer, fields []env.Field) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("environment:\n")
+	writeYAMLMap(bw, "  ", fields)
+	return bw.Flush()
+}
+
+// WriteKubernetes writes the fields as Kubernetes ConfigMap and Secret manifests with the specified name,
+// the fields tagged with option 'secret' are written to the Secret and others are written to the ConfigMap.
+// The manifest is omitted if there are no fields for it.
+func WriteKubernetes(w io.Writer, name string, fields []env.Field) error {
+	var configs, secrets []env.Field
+	for _, f := range fields {
+		if f.Secret {
+			secrets = append(secrets, f)
+		} else {
+			configs = append(configs, f)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	if len(configs) != 0 {
+		bw.WriteString("apiVersion: v1\n")
+		bw.WriteString("kind: ConfigMap\n")
+		bw.WriteString("metadata:\n")
+		fmt.Fprintf(bw, "  name: %s\n", strconv.Quote(name))
+		bw.WriteString("data:\n")
+		writeYAMLMap(bw, "  ", configs)
+	}
+	if len(secrets) != 0 {
+		if len(configs) != 0 {
+			bw.WriteString("---\n")
+		}
+		bw.WriteString("apiVersion: v1\n")
+		bw.WriteString("kind: Secret\n")
+		bw.WriteString("metadata:\n")
+		fmt.Fprintf(bw, "  name: %s\n", strconv.Quote(name))
+		bw.WriteString("type: Opaque\n")
+		bw.WriteString("data:\n")
+		for _, f := range secrets {
+			fmt.Fprintf(bw, "  %s: %s\n", key(f), strconv.Quote(base64.StdEncoding.EncodeToString([]byte(value(f)))))
+		}
+	}
+	return bw.Flush()
+}
+
+// key return the environment variable name of the field.
+func key(f env.Field) string {
+	return strings.ToUpper(f.Key)
+}
+
+// value return the current value of the field, or the default value if the current value is zero.
+func value(f env.Field) string {
+	if f.Value != "" {
+		return f.Value
+	}
+	return f.Default
+}
+
+func writeComment(w *bufio.Writer, f env.Field) {
+	if f.Usage == "" {
+		return
+	}
+	for _, line := range strings.Split(f.Usage, "\n") {
+		fmt.Fprintf(w, "# %s\n", line)
+	}
+}
+
+// writeYAMLMap writes the fields as YAML mapping, the value is always double-quoted
+// so the value such as "true" and "8080" is kept as string.
+func writeYAMLMap(w *bufio.Writer, indent string, fields []env.Field) {
+	for _, f := range fields {
+		fmt.Fprintf(w, "%s%s: %s\n", indent, key(f), strconv.Quote(value(f)))
+	}
+}
+
+// quoteDotEnv quote the value with double quotes if it contains special characters.
+func quoteDotEnv(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\r\n\"'`$\\#=") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, `$`, `\$`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// quoteShell quote the value with single quotes for POSIX shell.
+func quoteShell(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,106 @@
+package envexport_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envexport"
+)
+
+type DB struct {
+	Host     string `env:"HOST,default=localhost"`
+	Password string `env:"PASSWORD,secret"`
+}
+
+type Config struct {
+	Port    int               `env:"PORT,default=8080" desc:"port to listen"`
+	Timeout time.Duration     `env:"TIMEOUT,default=10s"`
+	Users   []string          `env:"USERS"`
+	Labels  map[string]string `env:"LABELS"`
+	Message string            `env:"MESSAGE,default=it's $HOME"`
+	DB      DB                `env:"DB"`
+}
+
+func fields(t *testing.T) []env.Field {
+	cfg := &Config{
+		Timeout: time.Minute,
+		Users:   []string{"rob", "ken"},
+		Labels:  map[string]string{"b": "2", "a": "1"},
+		DB:      DB{Password: "p@ss"},
+	}
+	fields, err := env.New(env.WithPrefix("myapp")).Fields(cfg)
+	require.Nil(t, err, "%+v", err)
+	return fields
+}
+
+func TestWriteDotEnv(t *testing.T) {
+	var buf bytes.Buffer
+	require.Nil(t, envexport.WriteDotEnv(&buf, fields(t)))
+	require.Equal(t, `# port to listen
+MYAPP_PORT=8080
+MYAPP_TIMEOUT=1m0s
+MYAPP_USERS="rob ken"
+MYAPP_LABELS="a:1 b:2"
+MYAPP_MESSAGE="it's \$HOME"
+MYAPP_DB_HOST=localhost
+MYAPP_DB_PASSWORD=p@ss
+`, buf.String())
+}
+
+func TestWriteShell(t *testing.T) {
+	var buf bytes.Buffer
+	require.Nil(t, envexport.WriteShell(&buf, fields(t)))
+	require.Equal(t, `#!/bin/sh
+# port to listen
+export MYAPP_PORT='8080'
+export MYAPP_TIMEOUT='1m0s'
+export MYAPP_USERS='rob ken'
+export MYAPP_LABELS='a:1 b:2'
+export MYAPP_MESSAGE='it'\''s $HOME'
+export MYAPP_DB_HOST='localhost'
+export MYAPP_DB_PASSWORD='p@ss'
+`, buf.String())
+}
+
+func TestWriteCompose(t *testing.T) {
+	var buf bytes.Buffer
+	require.Nil(t, envexport.WriteCompose(&buf, fields(t)))
+	require.Equal(t, `environment:
+  MYAPP_PORT: "8080"
+  MYAPP_TIMEOUT: "1m0s"
+  MYAPP_USERS: "rob ken"
+  MYAPP_LABELS: "a:1 b:2"
+  MYAPP_MESSAGE: "it's $HOME"
+  MYAPP_DB_HOST: "localhost"
+  MYAPP_DB_PASSWORD: "p@ss"
+`, buf.String())
+}
+
+func TestWriteKubernetes(t *testing.T) {
+	var buf bytes.Buffer
+	require.Nil(t, envexport.WriteKubernetes(&buf, "myapp", fields(t)))
+	require.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: "myapp"
+data:
+  MYAPP_PORT: "8080"
+  MYAPP_TIMEOUT: "1m0s"
+  MYAPP_USERS: "rob ken"
+  MYAPP_LABELS: "a:1 b:2"
+  MYAPP_MESSAGE: "it's $HOME"
+  MYAPP_DB_HOST: "localhost"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: "myapp"
+type: Opaque
+data:
+  MYAPP_DB_PASSWORD: "cEBzcw=="
+`, buf.String())
+}
@@ -0,0 +1,61 @@
+// Package envpflag binds the struct fields of env.Loader to the flags of github.com/spf13/pflag,
+// it also works with github.com/spf13/cobra by cmd.Flags().
+package envpflag
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/yu31/env"
+)
+
+// BindFlags defines a flag on fs for every field of the struct, and the Loader prefers the
+// value of flags that set on the command line over the value from Getter. See env.Loader.BindFlags.
+func BindFlags(l *env.Loader, fs *pflag.FlagSet, i interface{}) error {
+	return l.BindFlags(&flagSet{fs: fs}, i)
+}
+
+// flagSet implements env.FlagSet with pflag.
+type flagSet struct {
+	fs *pflag.FlagSet
+}
+
+func (s *flagSet) Define(name string, value string, usage string, isBool bool) error {
+	if s.fs.Lookup(name) != nil {
+		return env.ErrFlagDefined
+	}
+	f := s.fs.VarPF(&flagValue{value: value, isBool: isBool}, name, "", usage)
+	if isBool {
+		f.NoOptDefVal = "true"
+	}
+	return nil
+}
+
+func (s *flagSet) Lookup(name string) (string, bool) {
+	f := s.fs.Lookup(name)
+	if f == nil || !f.Changed {
+		return "", false
+	}
+	return f.Value.String(), true
+}
+
+// flagValue implements pflag.Value, the value is kept as is and converted by Load.
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (v *flagValue) String() string {
+	return v.value
+}
+
+func (v *flagValue) Set(value string) error {
+	v.value = value
+	return nil
+}
+
+func (v *flagValue) Type() string {
+	if v.isBool {
+		return "bool"
+	}
+	return "string"
+}
@@ -0,0 +1,56 @@
+package envpflag_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envpflag"
+	"github.com/yu31/env/envtest"
+)
+
+type Config struct {
+	HTTPPort int      `env:"HTTP_PORT,default=8080" desc:"port to listen"`
+	Debug    bool     `env:"DEBUG"`
+	Users    []string `env:"USERS"`
+	Address  string   `env:"ADDRESS"`
+}
+
+func TestBindFlags(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	l := envtest.NewLoader(map[string]string{
+		"MYAPP_HTTP_PORT": "9090",
+		"MYAPP_ADDRESS":   "127.0.0.1",
+	}, env.WithPrefix("MYAPP"))
+
+	cfg := &Config{}
+	require.Nil(t, envpflag.BindFlags(l, fs, cfg))
+
+	f := fs.Lookup("http-port")
+	require.NotNil(t, f)
+	require.Equal(t, "8080", f.DefValue)
+	require.Equal(t, "port to listen", f.Usage)
+
+	err := fs.Parse([]string{"--debug", "--users", "rob ken"})
+	require.Nil(t, err, "%+v", err)
+
+	err = l.Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, 9090, cfg.HTTPPort)
+	require.True(t, cfg.Debug)
+	require.Equal(t, []string{"rob", "ken"}, cfg.Users)
+	require.Equal(t, "127.0.0.1", cfg.Address)
+}
+
+func TestBindFlags_Defined(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.Bool("debug", false, "the flag of application")
+
+	err := envpflag.BindFlags(env.New(), fs, &Config{})
+	require.NotNil(t, err)
+	require.True(t, errors.Is(err, env.ErrFlagDefined))
+	require.Equal(t, "env: assigning 'Config.Debug': defining flag 'debug': flag is already defined", err.Error())
+}
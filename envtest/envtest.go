@@ -0,0 +1,42 @@
+// Package envtest provides helpers to set environment variables in tests
+// and to load config without touching the process environment.
+package envtest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/yu31/env"
+)
+
+// Set sets the environment variables by t.Setenv, they are restored when the test finishes.
+//
+// The environment variables are shared by the process, so Set panics in parallel tests
+// as t.Setenv. Use NewLoader instead for the parallel tests.
+func Set(t testing.TB, envs map[string]string) {
+	t.Helper()
+	for key, value := range envs {
+		t.Setenv(key, value)
+	}
+}
+
+// Unset unsets the environment variables, they are restored when the test finishes.
+// It panics in parallel tests as Set.
+func Unset(t testing.TB, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		// t.Setenv registers the cleanup to restore the variable
+		t.Setenv(key, "")
+		if err := os.Unsetenv(key); err != nil {
+			t.Fatalf("envtest: unset environment variable '%s': %v", key, err)
+		}
+	}
+}
+
+// NewLoader return a Loader that get value from envs only, it's isolated from the process
+// environment entirely and safe to use in parallel tests.
+// The Getter set by options is ignored.
+func NewLoader(envs map[string]string, options ...env.Option) *env.Loader {
+	options = append(options, env.WithGetter(env.MapGetter(envs)))
+	return env.New(options...)
+}
@@ -0,0 +1,79 @@
+package envtest_test
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+type Config struct {
+	Address string   `env:"ADDRESS"`
+	Port    int      `env:"PORT,default=80"`
+	Users   []string `env:"USERS"`
+}
+
+func TestSet(t *testing.T) {
+	t.Setenv("ENVTEST_EXISTING", "old")
+	envtest.Unset(t, "ENVTEST_NEW")
+
+	t.Run("set", func(t *testing.T) {
+		envtest.Set(t, map[string]string{
+			"ENVTEST_EXISTING": "new",
+			"ENVTEST_NEW":      "value",
+		})
+		require.Equal(t, "new", os.Getenv("ENVTEST_EXISTING"))
+		require.Equal(t, "value", os.Getenv("ENVTEST_NEW"))
+	})
+	require.Equal(t, "old", os.Getenv("ENVTEST_EXISTING"))
+	_, found := os.LookupEnv("ENVTEST_NEW")
+	require.False(t, found)
+
+	t.Run("unset", func(t *testing.T) {
+		envtest.Unset(t, "ENVTEST_EXISTING")
+		_, found := os.LookupEnv("ENVTEST_EXISTING")
+		require.False(t, found)
+	})
+	require.Equal(t, "old", os.Getenv("ENVTEST_EXISTING"))
+}
+
+func TestSet_Parallel(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		t.Parallel()
+		require.Panics(t, func() { envtest.Set(t, map[string]string{"ENVTEST_NEW": "value"}) })
+	})
+	t.Run("unset", func(t *testing.T) {
+		t.Parallel()
+		require.Panics(t, func() { envtest.Unset(t, "ENVTEST_NEW") })
+	})
+}
+
+func TestNewLoader(t *testing.T) {
+	for _, port := range []string{"8080", "9090"} {
+		port := port
+		t.Run(port, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{}
+			l := envtest.NewLoader(map[string]string{
+				"myapp_address": "127.0.0.1",
+				"MYAPP_PORT":    port,
+				"MYAPP_USERS":   "rob ken",
+			}, env.WithPrefix("MYAPP"))
+			require.Nil(t, l.Load(cfg))
+			require.Equal(t, "127.0.0.1", cfg.Address)
+			require.Equal(t, port, strconv.Itoa(cfg.Port))
+			require.Equal(t, []string{"rob", "ken"}, cfg.Users)
+		})
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{}
+		require.Nil(t, envtest.NewLoader(nil).Load(cfg))
+		require.Equal(t, 80, cfg.Port)
+	})
+}
@@ -2,23 +2,52 @@ package env
 
 import (
 	"errors"
-	"fmt"
+	"strings"
+
+	"github.com/yu31/env/envcore"
 )
 
 // ErrNotStructPtr is returned if you pass something that is not a pointer to a
 // Struct to Parse
 var ErrNotStructPtr = errors.New("env: expected a pointer to a Struct")
 
+// ErrNotFound is returned by Get if the key is not set
+var ErrNotFound = errors.New("env: key not found")
+
 // A ParseError occurs when an environment variable cannot be converted to
 // the type required by a struct field during assignment.
-type ParseError struct {
-	KeyName   string
-	FieldName string
-	TypeName  string
-	Value     string
-	Err       error
+// The Value and the details of Err are redacted if the field is tagged with option 'secret'.
+type ParseError = envcore.ParseError
+
+// redacted replaces the value of secret field in ParseError.
+const redacted = envcore.Redacted
+
+// RedactError return the error without the value of secret field, only the cause of
+// strconv.NumError is kept since it doesn't contain the value. It's used by ParseError
+// of secret field, including the loaders generated by envgen.
+func RedactError(err error) error {
+	return envcore.RedactError(err)
+}
+
+// A LintError occurs when the struct has problems found before any value is read,
+// such as duplicate keys, invalid tag options and unsupported field types.
+type LintError struct {
+	Issues []LintIssue
+}
+
+// LintIssue describes a problem of struct field.
+type LintIssue struct {
+	// Field is the struct name and field name, e.g. "Config.Port".
+	Field string
+	// Key is the merged key of field, it's empty if the tag cannot be parsed.
+	Key string
+	Err error
 }
 
-func (e *ParseError) Error() string {
-	return fmt.Sprintf("env: assigning '%s' to '%s': converting '%s' to type '%s'. details: %s", e.KeyName, e.FieldName, e.Value, e.TypeName, e.Err)
+func (e *LintError) Error() string {
+	msgs := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		msgs[i] = issue.Err.Error()
+	}
+	return strings.Join(msgs, "\n")
 }
@@ -0,0 +1,206 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	descTagName = "desc"
+)
+
+// Field describes a struct field that populated by Loader.
+type Field struct {
+	// Key is the key merged with prefix by Getter.Merge, it's used to get value from Getter.
+	Key string
+	// Path is the tag keys from the root struct to the field, without the prefix set by WithPrefix.
+	Path []string
+	// Name is the struct name and field name, e.g. "Config.Port".
+	Name string
+	// Type is the type of the field.
+	Type reflect.Type
+	// Default is the value of tag option 'default', or 'default@<profile>' of the profile set by WithProfile or WithProfileKey.
+	Default string
+	// Usage is the value of struct tag 'desc'.
+	Usage string
+	// Value is the current value of the field formatted in the form that can be loaded,
+	// it's empty if the value is zero.
+	Value string
+	// Secret reports whether the field is tagged with option 'secret'.
+	Secret bool
+}
+
+// Fields return the fields of the struct that populated by Load, the fields of nested structs
+// are expanded instead of the nested struct itself. The struct is not modified.
+// It returns *LintError if the struct has problems, see Load.
+func (p *Loader) Fields(i interface{}) ([]Field, error) {
+	p.lazyInit()
+	refVal := reflect.ValueOf(i)
+	if refVal.Kind() != reflect.Ptr || refVal.Type().Elem().Kind() != reflect.Struct {
+		return nil, ErrNotStructPtr
+	}
+	if refVal.IsNil() {
+		refVal = reflect.New(refVal.Type().Elem())
+	}
+
+	profile, err := p.profile()
+	if err != nil {
+		return nil, err
+	}
+	w := &fieldWalker{keys: make(map[string]string), collect: true, profile: profile}
+	p.walkFields(w, refVal.Elem(), p.opts.prefix, nil)
+	if len(w.issues) != 0 {
+		return nil, &LintError{Issues: w.issues}
+	}
+	return w.fields, nil
+}
+
+// analyze checks the struct before any value is read, return *LintError if any problem found.
+func (p *Loader) analyze(refVal reflect.Value, prefix string) error {
+	w := &fieldWalker{keys: make(map[string]string)}
+	p.walkFields(w, refVal, prefix, nil)
+	if len(w.issues) != 0 {
+		return &LintError{Issues: w.issues}
+	}
+	return nil
+}
+
+// fieldWalker maintains the state of walkFields.
+type fieldWalker struct {
+	keys    map[string]string // key -> field name, to detect duplicate keys
+	collect bool              // whether to collect the fields
+	profile string            // the profile to select the default of collected fields
+	fields  []Field
+	issues  []LintIssue
+}
+
+func (w *fieldWalker) report(name string, key string, err error) {
+	w.issues = append(w.issues, LintIssue{Field: name, Key: key, Err: err})
+}
+
+// walkFields walks the fields in the same way as loadValue.
+func (p *Loader) walkFields(w *fieldWalker, refVal reflect.Value, prefix string, path []string) {
+	refType := refVal.Type()
+	for i := 0; i < refType.NumField(); i++ {
+		structField := refType.Field(i)
+		name := refType.Name() + "." + structField.Name
+
+		// unexported field cannot be set, except the fields of unexported embedded struct
+		if structField.PkgPath != "" && !(structField.Anonymous && structField.Type.Kind() == reflect.Struct) {
+			if key := structField.Tag.Get(p.opts.tagName); key != "" && key != "-" {
+				w.report(name, "", fmt.Errorf("env: assigning '%s': unexported field cannot be set, tag '%s'", name, structField.Tag))
+			}
+			continue
+		}
+
+		tag, err := p.parseTags(name, structField)
+		if err != nil {
+			w.report(name, "", err)
+			continue
+		}
+		if tag == nil {
+			continue
+		}
+
+		if tag.inline {
+			field := refVal.Field(i)
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					field = reflect.New(field.Type().Elem())
+				}
+				field = field.Elem()
+			}
+			p.walkFields(w, field, prefix, path)
+			continue
+		}
+		if structField.PkgPath != "" {
+			w.report(name, "", fmt.Errorf("env: assigning '%s': unexported field cannot be set, tag '%s'", name, structField.Tag))
+			continue
+		}
+
+		// the path restarts from the absolute key
+		base := path
+		if tag.absolute {
+			base = nil
+		}
+		key := p.mergeKey(prefix, tag.key, tag)
+		fieldPath := append(base[:len(base):len(base)], tag.key)
+
+		field := refVal.Field(i)
+		if tag.format == "" {
+			if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct && p.lookupParser(field.Type()) == nil {
+				if field.IsNil() {
+					field = reflect.New(field.Type().Elem())
+				}
+				field = field.Elem()
+			}
+			if p.isNestedStruct(field) {
+				nestedPath := base[:len(base):len(base)]
+				if segment := tag.segment(); segment != "" {
+					nestedPath = append(nestedPath, segment)
+				}
+				p.walkFields(w, field, p.mergeKey(prefix, tag.segment(), tag), nestedPath)
+				continue
+			}
+		}
+
+		if other, ok := w.keys[strings.ToUpper(key)]; ok {
+			w.report(name, key, fmt.Errorf("env: assigning '%s': key '%s' is already used by '%s'", name, key, other))
+			continue
+		}
+		w.keys[strings.ToUpper(key)] = name
+
+		if !p.isSupportedType(structField.Type, tag) {
+			w.report(name, key, fmt.Errorf("env: assigning '%s': type '%s' is not supported", name, structField.Type))
+			continue
+		}
+
+		if w.collect {
+			w.fields = append(w.fields, Field{
+				Key:     key,
+				Path:    fieldPath,
+				Name:    name,
+				Type:    structField.Type,
+				Default: tag.defaultValue(w.profile),
+				Usage:   structField.Tag.Get(descTagName),
+				Value:   formatField(refVal.Field(i), tag),
+				Secret:  tag.secret,
+			})
+		}
+	}
+}
+
+// isSupportedType reports whether the value can be set to the type by setField.
+func (p *Loader) isSupportedType(t reflect.Type, tag *tagInfo) bool {
+	if tag.format != "" || p.lookupParser(t) != nil {
+		return true
+	}
+	if elem, ok := optionalElem(t); ok {
+		return p.isSupportedType(elem, tag)
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if p.lookupParser(t) != nil {
+			return true
+		}
+	}
+	return p.isSupportedElem(t, tag)
+}
+
+func (p *Loader) isSupportedElem(t reflect.Type, tag *tagInfo) bool {
+	if hasSetters(t) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Slice, reflect.Array:
+		return t.Elem().Kind() == reflect.Uint8 || p.isSupportedType(t.Elem(), tag)
+	case reflect.Map:
+		return p.isSupportedType(t.Key(), tag) && p.isSupportedType(t.Elem(), tag)
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// NewJSONGetter return a Getter that get value from a JSON document.
+//
+// The document is flattened into keys by Getter.Merge, so the nested objects map to nested structs:
+//
+//	{"db": {"host": "127.0.0.1", "ports": [5432, 5433]}}
+//
+// is flattened to:
+//
+//	DB_HOST=127.0.0.1
+//	DB_PORTS=5432 5433
+//	DB_PORTS_0=5432
+//	DB_PORTS_1=5433
+//
+// An array of scalars is also joined by space and an object of scalars is also joined as "k1:v1 k2:v2",
+// so they can be set to slice and map fields.
+func NewJSONGetter(data []byte) (Getter, error) {
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("env: parse json: %w", err)
+	}
+	return newDocumentGetter(doc)
+}
+
+// NewYAMLGetter return a Getter that get value from a YAML document.
+// The document is flattened in the same way as NewJSONGetter.
+func NewYAMLGetter(data []byte) (Getter, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("env: parse yaml: %w", err)
+	}
+	return newDocumentGetter(doc)
+}
+
+// NewTOMLGetter return a Getter that get value from a TOML document.
+// The document is flattened in the same way as NewJSONGetter.
+func NewTOMLGetter(data []byte) (Getter, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("env: parse toml: %w", err)
+	}
+	return newDocumentGetter(doc)
+}
+
+// NewFileGetter return a Getter that get value from the specified file,
+// the format is determined by the file extension: .json, .yaml, .yml, .toml, .properties or .ini.
+func NewFileGetter(path string) (Getter, error) {
+	var parse func(data []byte) (Getter, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		parse = NewJSONGetter
+	case ".yaml", ".yml":
+		parse = NewYAMLGetter
+	case ".toml":
+		parse = NewTOMLGetter
+	case ".properties":
+		parse = NewPropertiesGetter
+	case ".ini":
+		parse = NewINIGetter
+	default:
+		return nil, fmt.Errorf("env: unsupported config file format '%s'", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parse(data)
+}
+
+func newDocumentGetter(doc interface{}) (Getter, error) {
+	g := newMapGetter(nil)
+	if doc == nil {
+		return g, nil
+	}
+	if _, ok := normalizeObject(doc); !ok {
+		return nil, fmt.Errorf("env: expected an object at the top level of document but got %T", doc)
+	}
+	flatten(g, "", doc)
+	return g, nil
+}
+
+// flatten set the value and its nested values with the key to getter.
+func flatten(g *mapGetter, key string, value interface{}) {
+	if obj, ok := normalizeObject(value); ok {
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		pairs := make([]string, 0, len(obj))
+		for _, name := range names {
+			flatten(g, g.Merge(key, name), obj[name])
+			if s, ok := formatScalar(obj[name]); ok && pairs != nil {
+				pairs = append(pairs, name+":"+s)
+			} else {
+				pairs = nil
+			}
+		}
+		if key != "" && len(pairs) != 0 {
+			g.values[strings.ToUpper(key)] = strings.Join(pairs, " ")
+		}
+		return
+	}
+
+	if tables, ok := value.([]map[string]interface{}); ok {
+		arr := make([]interface{}, len(tables))
+		for i := range tables {
+			arr[i] = tables[i]
+		}
+		value = arr
+	}
+	if arr, ok := value.([]interface{}); ok {
+		items := make([]string, 0, len(arr))
+		for i, item := range arr {
+			flatten(g, g.Merge(key, strconv.Itoa(i)), item)
+			if s, ok := formatScalar(item); ok && items != nil {
+				items = append(items, s)
+			} else {
+				items = nil
+			}
+		}
+		if items != nil {
+			g.values[strings.ToUpper(key)] = strings.Join(items, " ")
+		}
+		return
+	}
+
+	if s, ok := formatScalar(value); ok {
+		g.values[strings.ToUpper(key)] = s
+	}
+}
+
+// normalizeObject convert the decoded object to map[string]interface{}.
+func normalizeObject(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			obj[fmt.Sprint(k)] = x
+		}
+		return obj, true
+	}
+	return nil, false
+}
+
+// formatScalar format the scalar value to string, return false if it's not a scalar.
+func formatScalar(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	case map[string]interface{}, map[interface{}]interface{}, []interface{}, []map[string]interface{}:
+		return "", false
+	default:
+		return fmt.Sprint(v), true
+	}
+}
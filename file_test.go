@@ -0,0 +1,142 @@
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+)
+
+type FileServer struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT"`
+}
+
+type FileConfig struct {
+	Address    string         `env:"ADDRESS"`
+	Port       int            `env:"PORT"`
+	Timeout    time.Duration  `env:"TIMEOUT"`
+	Rate       float64        `env:"RATE"`
+	Debug      bool           `env:"DEBUG"`
+	Users      []string       `env:"USERS"`
+	ColorCodes map[string]int `env:"COLORCODES"`
+	Embedded   *Embedded2     `env:"EMBEDDED"`
+	Server0    FileServer     `env:"SERVERS_0"`
+	Server1    FileServer     `env:"SERVERS_1"`
+	Message    string         `env:"MESSAGE,default=Hello World"`
+}
+
+var fileDocs = map[string]string{
+	"config.json": `{
+	"myapp": {
+		"address": "127.0.0.1",
+		"port": 8080,
+		"timeout": "30s",
+		"rate": 1000000.5,
+		"debug": true,
+		"users": ["rob", "ken", "robert"],
+		"colorcodes": {"red": 1, "green": 2, "blue": 3},
+		"embedded": {"Em2Int": 10, "Em2IntSlice": [1, 2]},
+		"servers": [{"host": "a", "port": 1}, {"host": "b", "port": 2}]
+	}
+}`,
+	"config.yaml": `
+myapp:
+  address: 127.0.0.1
+  port: 8080
+  timeout: 30s
+  rate: 1000000.5
+  debug: true
+  users: [rob, ken, robert]
+  colorcodes:
+    red: 1
+    green: 2
+    blue: 3
+  embedded:
+    Em2Int: 10
+    Em2IntSlice: [1, 2]
+  servers:
+    - host: a
+      port: 1
+    - host: b
+      port: 2
+`,
+	"config.toml": `
+[myapp]
+address = "127.0.0.1"
+port = 8080
+timeout = "30s"
+rate = 1000000.5
+debug = true
+users = ["rob", "ken", "robert"]
+
+[myapp.colorcodes]
+red = 1
+green = 2
+blue = 3
+
+[myapp.embedded]
+Em2Int = 10
+Em2IntSlice = [1, 2]
+
+[[myapp.servers]]
+host = "a"
+port = 1
+
+[[myapp.servers]]
+host = "b"
+port = 2
+`,
+}
+
+func TestEnv_Load_FileGetter(t *testing.T) {
+	dir := t.TempDir()
+	for name, doc := range fileDocs {
+		path := filepath.Join(dir, name)
+		require.Nil(t, os.WriteFile(path, []byte(doc), 0o600))
+
+		g, err := env.NewFileGetter(path)
+		require.Nil(t, err, "%s: %+v", name, err)
+
+		cfg := &FileConfig{}
+		err = env.New(env.WithPrefix("MYAPP"), env.WithGetter(g)).Load(cfg)
+		require.Nil(t, err, "%s: %+v", name, err)
+
+		require.Equal(t, "127.0.0.1", cfg.Address, name)
+		require.Equal(t, 8080, cfg.Port, name)
+		require.Equal(t, time.Second*30, cfg.Timeout, name)
+		require.Equal(t, 1000000.5, cfg.Rate, name)
+		require.True(t, cfg.Debug, name)
+		require.Equal(t, []string{"rob", "ken", "robert"}, cfg.Users, name)
+		require.Equal(t, map[string]int{"red": 1, "green": 2, "blue": 3}, cfg.ColorCodes, name)
+		require.Equal(t, 10, cfg.Embedded.Em2Int, name)
+		require.Equal(t, []int{1, 2}, cfg.Embedded.Em2IntSlice, name)
+		require.Equal(t, FileServer{Host: "a", Port: 1}, cfg.Server0, name)
+		require.Equal(t, FileServer{Host: "b", Port: 2}, cfg.Server1, name)
+		require.Equal(t, "Hello World", cfg.Message, name)
+
+		port, err := env.Get[int]("MYAPP_USERS_1", env.WithGetter(g))
+		require.NotNil(t, err, name)
+		require.Equal(t, 0, port)
+		require.Equal(t, "ken", env.MustGet[string]("MYAPP_USERS_1", env.WithGetter(g)), name)
+	}
+}
+
+func TestEnv_FileGetter_Invalid(t *testing.T) {
+	_, err := env.NewJSONGetter([]byte(`{`))
+	require.NotNil(t, err)
+	_, err = env.NewJSONGetter([]byte(`[1, 2]`))
+	require.NotNil(t, err)
+	_, err = env.NewYAMLGetter([]byte("a: [1"))
+	require.NotNil(t, err)
+	_, err = env.NewTOMLGetter([]byte("a = "))
+	require.NotNil(t, err)
+	_, err = env.NewFileGetter("config.xml")
+	require.NotNil(t, err)
+	_, err = env.NewFileGetter(filepath.Join(t.TempDir(), "notfound.json"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,138 @@
+package env
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FlagSet is implemented by command-line flag set, so that the Loader can work with
+// the flag libraries other than the standard package flag.
+type FlagSet interface {
+	// Define defines a flag with the specified name, default value and usage,
+	// a boolean flag can be set without value such as "--debug".
+	// Return ErrFlagDefined if the flag is already defined.
+	Define(name string, value string, usage string, isBool bool) error
+
+	// Lookup return (value, set) of the specified flag, set is true only if
+	// the flag was set on the command line.
+	Lookup(name string) (string, bool)
+}
+
+// ErrFlagDefined is returned by FlagSet.Define if the flag is already defined.
+var ErrFlagDefined = errors.New("flag is already defined")
+
+// FlagName return the flag name of the tag keys, e.g. ["HTTP", "PORT"] -> "http-port".
+func FlagName(path []string) string {
+	name := strings.Join(path, "-")
+	name = strings.ReplaceAll(name, "_", "-")
+	return strings.ToLower(name)
+}
+
+// RegisterFlags defines a flag on fs for every field of the struct. See BindFlags for details.
+func (p *Loader) RegisterFlags(fs *flag.FlagSet, i interface{}) error {
+	return p.BindFlags(&stdFlagSet{fs: fs}, i)
+}
+
+// BindFlags defines a flag on fs for every field of the struct, the flag name is
+// derived from the tag keys without prefix by FlagName, the default value is from
+// tag option 'default' and the usage is from struct tag 'desc'.
+//
+// After BindFlags, the Load prefers the value of flags that set on the command line
+// over the value from Getter, so the flags must be parsed before Load. The flags are
+// kept apart from the Getter, and a later call with other FlagSet replaces the flags
+// bound before. An error is returned if a flag is already defined on fs, such as by
+// the application or the former call with the same fs.
+func (p *Loader) BindFlags(fs FlagSet, i interface{}) error {
+	fields, err := p.Fields(i)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]string, len(fields))
+	defined := make(map[string]string, len(fields))
+	for _, field := range fields {
+		name := FlagName(field.Path)
+		if other, ok := defined[name]; ok {
+			return fmt.Errorf("env: assigning '%s': flag '%s' is already defined by '%s'", field.Name, name, other)
+		}
+		defined[name] = field.Name
+
+		typ := field.Type
+		if elem, ok := optionalElem(typ); ok {
+			typ = elem
+		}
+		isBool := typ.Kind() == reflect.Bool || (typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Bool)
+		if err := fs.Define(name, field.Default, field.Usage, isBool); err != nil {
+			return fmt.Errorf("env: assigning '%s': defining flag '%s': %w", field.Name, name, err)
+		}
+		names[strings.ToUpper(field.Key)] = name
+	}
+
+	p.opts.flags = &boundFlags{fs: fs, names: names}
+	return nil
+}
+
+// boundFlags is the flags bound by BindFlags, it's consulted before the Getter.
+type boundFlags struct {
+	fs    FlagSet
+	names map[string]string // key -> flag name
+}
+
+// lookup return the value of flag of key, set is true only if the flag was set on the command line.
+func (f *boundFlags) lookup(key string) (string, bool) {
+	if name, ok := f.names[strings.ToUpper(key)]; ok {
+		return f.fs.Lookup(name)
+	}
+	return "", false
+}
+
+// stdFlagSet implements FlagSet with the standard package flag.
+type stdFlagSet struct {
+	fs *flag.FlagSet
+}
+
+func (s *stdFlagSet) Define(name string, value string, usage string, isBool bool) error {
+	if s.fs.Lookup(name) != nil {
+		return ErrFlagDefined
+	}
+	s.fs.Var(&flagValue{value: value, isBool: isBool}, name, usage)
+	return nil
+}
+
+func (s *stdFlagSet) Lookup(name string) (string, bool) {
+	set := false
+	s.fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	if !set {
+		return "", false
+	}
+	return s.fs.Lookup(name).Value.String(), true
+}
+
+// flagValue implements flag.Value, the value is kept as is and converted by Load.
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.value
+}
+
+func (v *flagValue) Set(value string) error {
+	v.value = value
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
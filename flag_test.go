@@ -0,0 +1,119 @@
+package env_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+type FlagDB struct {
+	Host string `env:"HOST,default=localhost" desc:"database host"`
+	Port int    `env:"PORT,default=5432"`
+}
+
+type FlagConfig struct {
+	HTTPPort int           `env:"HTTP_PORT,default=8080" desc:"port to listen"`
+	Debug    bool          `env:"DEBUG"`
+	Timeout  time.Duration `env:"TIMEOUT,default=10s"`
+	Users    []string      `env:"USERS"`
+	DB       *FlagDB       `env:"DB"`
+	Ignored  string
+}
+
+func TestLoader_Fields(t *testing.T) {
+	fields, err := env.New(env.WithPrefix("MYAPP")).Fields(&FlagConfig{})
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, []env.Field{
+		{Key: "MYAPP_HTTP_PORT", Path: []string{"HTTP_PORT"}, Name: "FlagConfig.HTTPPort", Type: reflect.TypeOf(0), Default: "8080", Usage: "port to listen"},
+		{Key: "MYAPP_DEBUG", Path: []string{"DEBUG"}, Name: "FlagConfig.Debug", Type: reflect.TypeOf(false)},
+		{Key: "MYAPP_TIMEOUT", Path: []string{"TIMEOUT"}, Name: "FlagConfig.Timeout", Type: reflect.TypeOf(time.Duration(0)), Default: "10s"},
+		{Key: "MYAPP_USERS", Path: []string{"USERS"}, Name: "FlagConfig.Users", Type: reflect.TypeOf([]string{})},
+		{Key: "MYAPP_DB_HOST", Path: []string{"DB", "HOST"}, Name: "FlagDB.Host", Type: reflect.TypeOf(""), Default: "localhost", Usage: "database host"},
+		{Key: "MYAPP_DB_PORT", Path: []string{"DB", "PORT"}, Name: "FlagDB.Port", Type: reflect.TypeOf(0), Default: "5432"},
+	}, fields)
+
+	_, err = env.New().Fields(FlagConfig{})
+	require.Equal(t, env.ErrNotStructPtr, err)
+}
+
+func TestLoader_RegisterFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l := envtest.NewLoader(map[string]string{
+		"MYAPP_HTTP_PORT": "9090",
+		"MYAPP_TIMEOUT":   "30s",
+		"MYAPP_DB_HOST":   "db.local",
+	}, env.WithPrefix("MYAPP"))
+
+	cfg := &FlagConfig{}
+	require.Nil(t, l.RegisterFlags(fs, cfg))
+
+	var usage bytes.Buffer
+	fs.SetOutput(&usage)
+	fs.PrintDefaults()
+	require.True(t, strings.Contains(usage.String(), "-http-port value"), usage.String())
+	require.True(t, strings.Contains(usage.String(), "port to listen (default 8080)"), usage.String())
+	require.True(t, strings.Contains(usage.String(), "-db-host value"), usage.String())
+
+	err := fs.Parse([]string{"--http-port", "7070", "-debug", "--users=rob ken", "--db-port=3306"})
+	require.Nil(t, err, "%+v", err)
+
+	err = l.Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, 7070, cfg.HTTPPort)
+	require.True(t, cfg.Debug)
+	require.Equal(t, time.Second*30, cfg.Timeout)
+	require.Equal(t, []string{"rob", "ken"}, cfg.Users)
+	require.Equal(t, "db.local", cfg.DB.Host)
+	require.Equal(t, 3306, cfg.DB.Port)
+
+	type Duplicate struct {
+		A string `env:"A_B"`
+		B string `env:"A-B"`
+	}
+	require.NotNil(t, env.New().RegisterFlags(flag.NewFlagSet("test", flag.ContinueOnError), &Duplicate{}))
+}
+
+func TestLoader_RegisterFlags_Rebind(t *testing.T) {
+	l := envtest.NewLoader(map[string]string{"DEBUG": "true", "HTTP_PORT": "9090"})
+
+	first := flag.NewFlagSet("first", flag.ContinueOnError)
+	require.Nil(t, l.RegisterFlags(first, &FlagConfig{}))
+	require.Nil(t, first.Parse([]string{"--http-port", "6060", "--timeout", "1s"}))
+
+	// The flags bound later replace the former flags instead of stacking on them.
+	second := flag.NewFlagSet("second", flag.ContinueOnError)
+	require.Nil(t, l.RegisterFlags(second, &FlagConfig{}))
+	require.Nil(t, second.Parse([]string{"--http-port", "7070"}))
+
+	cfg := &FlagConfig{}
+	require.Nil(t, l.Load(cfg))
+	require.Equal(t, 7070, cfg.HTTPPort)
+	require.Equal(t, 10*time.Second, cfg.Timeout)
+	require.True(t, cfg.Debug)
+}
+
+func TestLoader_RegisterFlags_Defined(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l := env.New()
+	require.Nil(t, l.RegisterFlags(fs, &FlagConfig{}))
+
+	// The flags cannot be defined again on the same FlagSet.
+	err := l.RegisterFlags(fs, &FlagConfig{})
+	require.True(t, errors.Is(err, env.ErrFlagDefined))
+	require.Equal(t, "env: assigning 'FlagConfig.HTTPPort': defining flag 'http-port': flag is already defined", err.Error())
+
+	// The flag of application conflicts with the key.
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Bool("debug", false, "the flag of application")
+	err = l.RegisterFlags(fs, &FlagConfig{})
+	require.True(t, errors.Is(err, env.ErrFlagDefined))
+}
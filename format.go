@@ -0,0 +1,148 @@
+package env
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// formatField format the field value to string in the form that can be set by setField,
+// return empty string if the value is zero or cannot be formatted.
+func formatField(field reflect.Value, tag *tagInfo) string {
+	if !field.IsValid() || field.IsZero() {
+		return ""
+	}
+	if opt, ok := asOptional(field); ok {
+		return formatField(opt.elem(), tag)
+	}
+
+	var b []byte
+	var err error
+	switch tag.format {
+	case formatJSON:
+		b, err = json.Marshal(field.Interface())
+	case formatYAML:
+		b, err = yaml.Marshal(field.Interface())
+	default:
+		return formatValue(field, tag)
+	}
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(b), "\n")
+}
+
+func formatValue(field reflect.Value, tag *tagInfo) string {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
+
+	if field.Type() == timeType && (tag.layout != "" || tag.loc != nil) {
+		return formatTime(field.Interface().(time.Time), tag)
+	}
+	if field.CanInterface() {
+		v := field.Interface()
+		if field.CanAddr() {
+			v = field.Addr().Interface()
+		}
+		switch x := v.(type) {
+		case encoding.TextMarshaler:
+			b, err := x.MarshalText()
+			if err != nil {
+				return ""
+			}
+			return string(b)
+		case fmt.Stringer:
+			return x.String()
+		}
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if tag.unit == "bytes" && field.Int() >= 0 {
+			return ByteSize(field.Int()).String()
+		}
+		return strconv.FormatInt(field.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if tag.unit == "bytes" {
+			return ByteSize(field.Uint()).String()
+		}
+		return strconv.FormatUint(field.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, field.Type().Bits())
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool())
+	case reflect.Slice, reflect.Array:
+		if field.Type().Elem().Kind() == reflect.Uint8 && (field.Kind() == reflect.Slice || tag.encoding != "") {
+			b := make([]byte, field.Len())
+			for i := range b {
+				b[i] = byte(field.Index(i).Uint())
+			}
+			return encodeBytes(b, tag.encoding)
+		}
+		parts := make([]string, field.Len())
+		for i := range parts {
+			parts[i] = formatValue(field.Index(i), tag)
+		}
+		return strings.Join(parts, tag.separator())
+	case reflect.Map:
+		pairs := make([]string, 0, field.Len())
+		iter := field.MapRange()
+		for iter.Next() {
+			pairs = append(pairs, formatValue(iter.Key(), tag)+":"+formatValue(iter.Value(), tag))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, tag.separator())
+	default:
+		return ""
+	}
+}
+
+// formatTime format the time by the layout and location specified in tag, it's the inverse of parseTime.
+func formatTime(t time.Time, tag *tagInfo) string {
+	if tag.loc != nil {
+		t = t.In(tag.loc)
+	}
+	switch tag.layout {
+	case layoutUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case layoutUnixMilli:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	case layoutUnixMicro:
+		return strconv.FormatInt(t.UnixMicro(), 10)
+	case layoutUnixNano:
+		return strconv.FormatInt(t.UnixNano(), 10)
+	case "":
+		return t.Format(time.RFC3339Nano)
+	default:
+		return t.Format(tag.layout)
+	}
+}
+
+// encodeBytes encodes the bytes by the specified encoding, it's the inverse of decodeBytes.
+func encodeBytes(b []byte, encoding string) string {
+	switch encoding {
+	case encodingBase64:
+		return base64.StdEncoding.EncodeToString(b)
+	case encodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(b)
+	case encodingHex:
+		return hex.EncodeToString(b)
+	default:
+		return string(b)
+	}
+}
@@ -0,0 +1,118 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Get return the value of the specified key converted to type T.
+// The value is converted in the same way as the struct field by Load, the Option
+// such as WithPrefix and WithGetter are honored, and WithSeparator, WithLayout,
+// WithLocation, WithUnit and WithEncoding work as the tag options of struct field.
+// Return ErrNotFound if the key is not set or its value is empty.
+func Get[T any](key string, options ...Option) (T, error) {
+	var v T
+	found, err := New(options...).get(reflect.ValueOf(&v).Elem(), key)
+	if err != nil {
+		return v, err
+	}
+	if !found {
+		return v, fmt.Errorf("%w: '%s'", ErrNotFound, key)
+	}
+	return v, nil
+}
+
+// MustGet is like Get but panics if the key is not set or cannot be converted.
+func MustGet[T any](key string, options ...Option) T {
+	v, err := Get[T](key, options...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// GetOr is like Get but return the fallback if the key is not set or cannot be converted.
+func GetOr[T any](key string, fallback T, options ...Option) T {
+	v, err := Get[T](key, options...)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+// get set the value of the specified key to the field,
+// return false if the key is not set or its value is empty.
+func (p *Loader) get(field reflect.Value, key string) (bool, error) {
+	key = p.opts.getter.Merge(p.opts.prefix, key)
+	tag := p.opts.value
+	tag.key = key
+	if err := checkValueOptions(key, field.Type(), &tag); err != nil {
+		return false, err
+	}
+	value, found, err := p.lookup(key)
+	if err != nil {
+		return false, err
+	}
+	if !found || value == "" {
+		return false, nil
+	}
+	resolved, err := p.resolve(key, value)
+	if err != nil {
+		return false, err
+	}
+	plaintext, err := p.decrypt(key, resolved)
+	if err != nil || plaintext == "" {
+		return false, err
+	}
+	if err := p.setField(field, plaintext, &tag); err != nil {
+		pe := &ParseError{
+			KeyName:  key,
+			TypeName: field.Type().String(),
+			Value:    value,
+			Err:      err,
+		}
+		// the value replaced by resolving or decryption is secret as the field with tag option 'secret'
+		if plaintext != value {
+			pe.Value, pe.Err = redacted, RedactError(err)
+		}
+		return false, pe
+	}
+	return true, nil
+}
+
+// checkValueOptions checks the options of value converted by Get as checkTagType.
+func checkValueOptions(key string, t reflect.Type, tag *tagInfo) error {
+	check := func(option string, want string, set bool, ok func(t reflect.Type) bool) error {
+		if !set || matchValueType(t, ok) {
+			return nil
+		}
+		return fmt.Errorf("env: getting '%s': invalid option '%s', the type must be %s", key, option, want)
+	}
+
+	if tag.unit != "" && tag.unit != "bytes" {
+		return fmt.Errorf("env: getting '%s': invalid option 'WithUnit', unsupported unit '%s'", key, tag.unit)
+	}
+	if tag.encoding != "" && !isEncoding(tag.encoding) {
+		return fmt.Errorf("env: getting '%s': invalid option 'WithEncoding', unsupported encoding '%s', supported encodings: raw, base64, base64url, hex", key, tag.encoding)
+	}
+	if tag.sep != "" && !isCollection(t) {
+		return fmt.Errorf("env: getting '%s': invalid option 'WithSeparator', the type must be a slice, array or map", key)
+	}
+	if err := check("WithUnit", "an integer", tag.unit != "", isInteger); err != nil {
+		return err
+	}
+	if err := check("WithEncoding", "[]byte or [N]byte", tag.encoding != "", isBytes); err != nil {
+		return err
+	}
+	if err := check("WithLayout", "time.Time", tag.layout != "", isTime); err != nil {
+		return err
+	}
+	if err := check("WithLocation", "time.Time", tag.loc != nil, isTime); err != nil {
+		return err
+	}
+	if strings.Contains(tag.layout, " ") && tag.sep == "" && isCollection(t) {
+		return fmt.Errorf("env: getting '%s': invalid option 'WithLayout', the layout contains space, set the separator of elements by option 'WithSeparator'", key)
+	}
+	return nil
+}
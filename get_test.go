@@ -0,0 +1,89 @@
+package env_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+func TestGet(t *testing.T) {
+	envtest.Set(t, map[string]string{
+		"ENV_PORT":    "8080",
+		"ENV_TIMEOUT": "30s",
+		"ENV_USERS":   "rob ken",
+		"ENV_URL":     "http://127.0.0.1:8080",
+		"ENV_CUSTOM":  "Joe/man",
+		"ENV_BAD":     "x",
+	})
+	envtest.Unset(t, "ENV_NOTFOUND")
+
+	port, err := env.Get[int]("PORT", env.WithPrefix(prefix))
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, 8080, port)
+
+	timeout, err := env.Get[*time.Duration]("TIMEOUT", env.WithPrefix(prefix))
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, time.Second*30, *timeout)
+
+	require.Equal(t, []string{"rob", "ken"}, env.MustGet[[]string]("USERS", env.WithPrefix(prefix)))
+	require.Equal(t, "http://127.0.0.1:8080", env.MustGet[*url.URL]("URL", env.WithPrefix(prefix)).String())
+	require.Equal(t, CustomList{Name: "Joe", Sex: "man"}, env.MustGet[CustomList]("CUSTOM", env.WithPrefix(prefix)))
+
+	_, err = env.Get[int]("NOTFOUND", env.WithPrefix(prefix))
+	require.True(t, errors.Is(err, env.ErrNotFound))
+
+	_, err = env.Get[int]("BAD", env.WithPrefix(prefix))
+	var pe *env.ParseError
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, "env_BAD", pe.KeyName)
+	require.Panics(t, func() { env.MustGet[int]("BAD", env.WithPrefix(prefix)) })
+
+	require.Equal(t, 10, env.GetOr("NOTFOUND", 10, env.WithPrefix(prefix)))
+	require.Equal(t, 10, env.GetOr("BAD", 10, env.WithPrefix(prefix)))
+	require.Equal(t, 8080, env.GetOr("PORT", 10, env.WithPrefix(prefix)))
+}
+
+func TestGet_Options(t *testing.T) {
+	envtest.Set(t, map[string]string{
+		"GET_LIST":  "1,2",
+		"GET_MAP":   "a:1;b:2",
+		"GET_TIME":  "Wed, 18 Nov 2020 15:09:42 UTC",
+		"GET_TIMES": "2020-11-18 15:09|2020-11-19 16:10",
+		"GET_SIZE":  "1.5KiB",
+		"GET_BYTES": "aGVsbG8=",
+	})
+
+	_, err := env.Get[[]int]("GET_LIST")
+	require.NotNil(t, err)
+	require.Equal(t, []int{1, 2}, env.MustGet[[]int]("GET_LIST", env.WithSeparator(",")))
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, env.MustGet[map[string]int]("GET_MAP", env.WithSeparator(";")))
+
+	require.Equal(t, time.Date(2020, 11, 18, 15, 9, 42, 0, time.UTC), env.MustGet[time.Time]("GET_TIME", env.WithLayout("RFC1123")))
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.Nil(t, err)
+	times := env.MustGet[[]time.Time]("GET_TIMES", env.WithLayout("2006-01-02 15:04"), env.WithLocation(loc), env.WithSeparator("|"))
+	require.Equal(t, []time.Time{time.Date(2020, 11, 18, 15, 9, 0, 0, loc), time.Date(2020, 11, 19, 16, 10, 0, 0, loc)}, times)
+
+	require.Equal(t, int64(1536), env.MustGet[int64]("GET_SIZE", env.WithUnit("bytes")))
+	require.Equal(t, []byte("hello"), env.MustGet[[]byte]("GET_BYTES", env.WithEncoding("base64")))
+
+	invalid := map[string]error{}
+	_, invalid["unit"] = env.Get[string]("GET_SIZE", env.WithUnit("bytes"))
+	_, invalid["unit value"] = env.Get[int]("GET_SIZE", env.WithUnit("bits"))
+	_, invalid["encoding"] = env.Get[string]("GET_BYTES", env.WithEncoding("base64"))
+	_, invalid["encoding value"] = env.Get[[]byte]("GET_BYTES", env.WithEncoding("base32"))
+	_, invalid["layout"] = env.Get[string]("GET_TIME", env.WithLayout("RFC1123"))
+	_, invalid["layout space"] = env.Get[[]time.Time]("GET_TIMES", env.WithLayout("2006-01-02 15:04"))
+	_, invalid["sep"] = env.Get[int]("GET_LIST", env.WithSeparator(","))
+	for name, err := range invalid {
+		require.NotNil(t, err, name)
+		require.Contains(t, err.Error(), "env: getting 'GET_", name)
+	}
+	require.Equal(t, "env: getting 'GET_SIZE': invalid option 'WithUnit', the type must be an integer", invalid["unit"].Error())
+}
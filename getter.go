@@ -3,15 +3,17 @@ package env
 import (
 	"os"
 	"strings"
+
+	"github.com/yu31/env/envcore"
 )
 
 // Getter is implemented by types can self-serialize keys.
-type Getter interface {
-	// Merge return a new key with merge prefix and key
-	Merge(prefix string, key string) string
+type Getter = envcore.Getter
 
-	// Get return (value, found, error) with specified key
-	Get(key string) (string, bool, error)
+// Unsetter is implemented by the Getter that can remove keys, see WithUnsetAfterRead.
+type Unsetter interface {
+	// Unset removes the specified key
+	Unset(key string) error
 }
 
 type getter struct{}
@@ -33,3 +35,45 @@ func (g *getter) Get(key string) (string, bool, error) {
 	value, found := os.LookupEnv(key)
 	return value, found, nil
 }
+
+func (g *getter) Unset(key string) error {
+	return os.Unsetenv(strings.ToUpper(key))
+}
+
+// MapGetter return a Getter that get value from the map instead of environment variables,
+// the key is case-insensitive. It's useful in tests to isolate from the process environment.
+func MapGetter(values map[string]string) Getter {
+	return newMapGetter(values)
+}
+
+// mapGetter get value from a map, the key is case-insensitive.
+type mapGetter struct {
+	getter
+	values map[string]string
+}
+
+func newMapGetter(values map[string]string) *mapGetter {
+	g := &mapGetter{values: make(map[string]string, len(values))}
+	for k, v := range values {
+		g.values[strings.ToUpper(k)] = v
+	}
+	return g
+}
+
+func (g *mapGetter) Get(key string) (string, bool, error) {
+	value, found := g.values[strings.ToUpper(key)]
+	return value, found, nil
+}
+
+func (g *mapGetter) Unset(key string) error {
+	delete(g.values, strings.ToUpper(key))
+	return nil
+}
+
+// mergePath merge the prefix and the dot-separated path by Merge.
+func (g *mapGetter) mergePath(prefix string, path string) string {
+	for _, key := range strings.Split(path, ".") {
+		prefix = g.Merge(prefix, key)
+	}
+	return prefix
+}
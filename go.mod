@@ -1,5 +1,15 @@
 module github.com/yu31/env
 
-go 1.15
+go 1.18
 
-require github.com/stretchr/testify v1.6.1
+require (
+	github.com/BurntSushi/toml v1.5.0
+	github.com/spf13/pflag v1.0.5
+	github.com/stretchr/testify v1.6.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
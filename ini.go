@@ -0,0 +1,52 @@
+package env
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// NewINIGetter return a Getter that get value from an INI document.
+//
+// The section is merged with key by Getter.Merge, so the key "host" in section "[db]"
+// is mapped to key "DB_HOST", and the keys before the first section have no prefix.
+// The separator of key and value can be '=' or ':', the line starts with ';' or '#' is comment,
+// and the value enclosed in double or single quotes is unquoted.
+func NewINIGetter(data []byte) (Getter, error) {
+	g := newMapGetter(nil)
+	section := ""
+	lineno := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lineno++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("env: parse ini: line %d: invalid section '%s'", lineno, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		i := strings.IndexAny(line, "=:")
+		if i <= 0 {
+			return nil, fmt.Errorf("env: parse ini: line %d: expected 'key = value' but got '%s'", lineno, line)
+		}
+		key := strings.TrimSpace(line[:i])
+		value := strings.TrimSpace(line[i+1:])
+		if n := len(value); n >= 2 && (value[0] == '"' || value[0] == '\'') && value[n-1] == value[0] {
+			value = value[1 : n-1]
+		}
+		g.values[strings.ToUpper(g.mergePath(g.mergePath("", section), key))] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
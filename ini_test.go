@@ -0,0 +1,61 @@
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+)
+
+var iniDoc = `
+; comment
+# comment
+debug = true
+
+[myapp]
+address = 127.0.0.1
+port: 8080
+timeout = "30s"
+users = rob ken robert
+colorcodes = 'red:1 green:2 blue:3'
+
+[myapp.embedded]
+Em2Int = 10
+
+[myapp.servers.0]
+host = a
+port = 1
+
+[ myapp.servers.1 ]
+host = b
+port = 2
+`
+
+func TestEnv_Load_INIGetter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.ini")
+	require.Nil(t, os.WriteFile(path, []byte(iniDoc), 0o600))
+	g, err := env.NewFileGetter(path)
+	require.Nil(t, err, "%+v", err)
+
+	cfg := &FileConfig{}
+	err = env.New(env.WithPrefix("MYAPP"), env.WithGetter(g)).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+
+	require.Equal(t, "127.0.0.1", cfg.Address)
+	require.Equal(t, 8080, cfg.Port)
+	require.Equal(t, "30s", cfg.Timeout.String())
+	require.Equal(t, []string{"rob", "ken", "robert"}, cfg.Users)
+	require.Equal(t, map[string]int{"red": 1, "green": 2, "blue": 3}, cfg.ColorCodes)
+	require.Equal(t, 10, cfg.Embedded.Em2Int)
+	require.Equal(t, FileServer{Host: "a", Port: 1}, cfg.Server0)
+	require.Equal(t, FileServer{Host: "b", Port: 2}, cfg.Server1)
+	require.True(t, env.MustGet[bool]("DEBUG", env.WithGetter(g)))
+
+	_, err = env.NewINIGetter([]byte("[section"))
+	require.NotNil(t, err)
+	_, err = env.NewINIGetter([]byte("novalue"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,816 @@
+// Package envgen generates reflection-free loaders for struct types with env tags.
+//
+// The generated method LoadEnv has the same semantics as env.Loader.Load with default
+// options: the secret references such as "ref+file://..." are kept as is since no resolver
+// is registered, the encrypted values "enc:v1:..." are reported as *env.DecryptError since
+// no decryption key is set, the nested structs are merged with prefix by Getter.Merge, the default value
+// is used only if the key is not set and the field is zero, the non-zero field is not
+// overridden, the types that implement env.Setter, encoding.TextUnmarshaler or
+// encoding.BinaryUnmarshaler set themselves, and the slice, array and map values are
+// split by space.
+//
+// The generated code imports package envcore instead of env, so that it doesn't depend on
+// reflect and the other dependencies of env.
+//
+// The anonymous structs without tag and the structs with tag option 'inline' or 'squash'
+// are flattened into the key space of parent.
+//
+// The tag options other than 'default', 'secret', 'inline', 'squash', 'prefix' and 'noprefix'
+// and the types that need the builtin parsers of env (such as net.IP and netip.Addr) are
+// not supported.
+package envgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	envPkgPath = "github.com/yu31/env"
+	// corePkgPath is the only package of env imported by the generated code.
+	corePkgPath = "github.com/yu31/env/envcore"
+
+	// Header is the first line of generated file.
+	Header = "// Code generated by envgen. DO NOT EDIT."
+)
+
+// Config configures the generator.
+type Config struct {
+	// Dir is the directory of the package.
+	Dir string
+	// Types is the names of struct types to generate LoadEnv.
+	Types []string
+	// TagName is the struct tag name, default "env".
+	TagName string
+}
+
+// Generate return the formatted source code of loaders of the struct types.
+func Generate(cfg Config) ([]byte, error) {
+	if cfg.TagName == "" {
+		cfg.TagName = "env"
+	}
+	pkg, err := loadPackage(cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		pkg:     pkg,
+		tagName: cfg.TagName,
+		imports: map[string]string{corePkgPath: "envcore"},
+		names:   map[string]string{"envcore": corePkgPath},
+		setters: make(map[string]string),
+		structs: make(map[*types.Named]bool),
+	}
+	for _, name := range cfg.Types {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			return nil, fmt.Errorf("envgen: type '%s' is not found in package '%s'", name, pkg.Name())
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok || !isStruct(named) {
+			return nil, fmt.Errorf("envgen: type '%s' is not a struct", name)
+		}
+		if err := g.checkKeys(named, "", make(map[string]string)); err != nil {
+			return nil, err
+		}
+		g.enqueue(named)
+	}
+	for len(g.queue) != 0 {
+		named := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.genStruct(named); err != nil {
+			return nil, err
+		}
+	}
+	return g.source()
+}
+
+// loadPackage parses and type-checks the package in dir, the generated files are excluded.
+func loadPackage(dir string) (*types.Package, error) {
+	bp, err := build.Default.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	imports := make(map[string]bool)
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if isGenerated(f) {
+			continue
+		}
+		files = append(files, f)
+		for _, spec := range f.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			imports[path] = true
+		}
+	}
+
+	imp, err := exportImporter(fset, dir, imports)
+	if err != nil {
+		return nil, err
+	}
+	conf := types.Config{Importer: imp}
+	return conf.Check(bp.ImportPath, fset, files, nil)
+}
+
+// exportImporter return an importer that reads the export data of the imported packages
+// and their dependencies built by "go list -export", so that they are built once and
+// cached by the go command instead of being type-checked from source by every Generate.
+func exportImporter(fset *token.FileSet, dir string, imports map[string]bool) (types.Importer, error) {
+	exports := make(map[string]string) // package path -> export data file
+	if len(imports) != 0 {
+		args := []string{"list", "-export", "-deps", "-f", "{{.ImportPath}} {{.Export}}"}
+		for path := range imports {
+			args = append(args, path)
+		}
+		var stderr bytes.Buffer
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("envgen: list the imported packages: %v\n%s", err, stderr.String())
+		}
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			if path, file, ok := strings.Cut(line, " "); ok {
+				exports[path] = file
+			}
+		}
+	}
+
+	return importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		file := exports[path]
+		if file == "" {
+			return nil, fmt.Errorf("no export data for package '%s'", path)
+		}
+		return os.Open(file)
+	}), nil
+}
+
+func isGenerated(f *ast.File) bool {
+	for _, c := range f.Comments {
+		for _, line := range c.List {
+			if line.Text == Header {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type generator struct {
+	pkg     *types.Package
+	tagName string
+
+	imports map[string]string // package path -> name
+	names   map[string]string // name -> package path
+	setters map[string]string // type -> setter function name
+	structs map[*types.Named]bool
+	queue   []*types.Named
+
+	loaders bytes.Buffer
+	funcs   bytes.Buffer
+}
+
+func (g *generator) enqueue(named *types.Named) {
+	if !g.structs[named] {
+		g.structs[named] = true
+		g.queue = append(g.queue, named)
+	}
+}
+
+// reserved is the identifiers declared in the generated functions, the imports must not be shadowed by them.
+var reserved = map[string]bool{
+	"c": true, "g": true, "prefix": true, "override": true, "key": true, "value": true, "found": true,
+	"err": true, "msgs": true, "dst": true, "parts": true, "part": true, "sl": true, "i": true,
+	"mp": true, "pair": true, "kv": true, "k": true, "v": true,
+}
+
+// use record the import of package and return its name, the name is suffixed with number
+// if it's used by other package or conflicts with identifiers in the generated code.
+func (g *generator) use(path string, name string) string {
+	if name, ok := g.imports[path]; ok {
+		return name
+	}
+	alias := name
+	for i := 2; g.names[alias] != "" || reserved[alias] || g.pkg.Scope().Lookup(alias) != nil; i++ {
+		alias = name + strconv.Itoa(i)
+	}
+	g.imports[path] = alias
+	g.names[alias] = path
+	return alias
+}
+
+// useStd record the import of standard package whose name is the last element of path.
+func (g *generator) useStd(path string) string {
+	return g.use(path, path[strings.LastIndex(path, "/")+1:])
+}
+
+// typeString return the type in source code.
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+		return g.use(p.Path(), p.Name())
+	})
+}
+
+// reflectString return the type in the form of reflect.Type.String.
+func reflectString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		return p.Name()
+	})
+}
+
+func (g *generator) source() ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n\n", Header)
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg.Name())
+
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	// the standard packages first, then the others
+	buf.WriteString("import (\n")
+	for _, std := range []bool{true, false} {
+		if !std {
+			buf.WriteString("\n")
+		}
+		for _, path := range paths {
+			if isStdPackage(path) == std {
+				if name := g.imports[path]; name != path[strings.LastIndex(path, "/")+1:] {
+					fmt.Fprintf(&buf, "\t%s %s\n", name, strconv.Quote(path))
+				} else {
+					fmt.Fprintf(&buf, "\t%s\n", strconv.Quote(path))
+				}
+			}
+		}
+	}
+	buf.WriteString(")\n\n")
+
+	buf.Write(g.loaders.Bytes())
+	buf.Write(g.funcs.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("envgen: format source: %w\n%s", err, buf.String())
+	}
+	return src, nil
+}
+
+func isStdPackage(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
+
+func (g *generator) genStruct(named *types.Named) error {
+	name := named.Obj().Name()
+	st := named.Underlying().(*types.Struct)
+	w := &g.loaders
+
+	fmt.Fprintf(w, "// LoadEnv populates c with the value from g in the same way as env.Loader.Load.\n")
+	fmt.Fprintf(w, "func (c *%s) LoadEnv(g envcore.Getter) error {\n", name)
+	fmt.Fprintf(w, "return c.LoadEnvWith(g, \"\", false)\n")
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "// LoadEnvWith is like LoadEnv but with prefix and override as env.WithPrefix and env.WithOverride.\n")
+	fmt.Fprintf(w, "func (c *%s) LoadEnvWith(g envcore.Getter, prefix string, override bool) error {\n", name)
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag, err := g.fieldTag(name, field, reflect.StructTag(st.Tag(i)))
+		if err != nil {
+			return err
+		}
+		if tag == nil {
+			continue
+		}
+		if err := g.genField(name, field, tag); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "return nil\n")
+	fmt.Fprintf(w, "}\n\n")
+	return nil
+}
+
+type tagInfo struct {
+	key      string
+	defVal   string
+	inline   bool
+	absolute bool
+	secret   bool
+	prefix   *string
+}
+
+// segment return the key that merged as prefix of nested struct.
+func (t *tagInfo) segment() string {
+	if t.prefix != nil {
+		return *t.prefix
+	}
+	return t.key
+}
+
+// parent return the prefix expression that merged with the key.
+func (t *tagInfo) parent() string {
+	if t.absolute {
+		return `""`
+	}
+	return "prefix"
+}
+
+// fieldTag return the tag of field that should be loaded, or nil if the field is skipped.
+func (g *generator) fieldTag(structName string, field *types.Var, structTag reflect.StructTag) (*tagInfo, error) {
+	// the fields of unexported embedded struct can be set
+	embedded := field.Embedded() && isStruct(field.Type()) && !isPointer(field.Type())
+	if !field.Exported() && !embedded {
+		if key := structTag.Get(g.tagName); key != "" && key != "-" {
+			return nil, fmt.Errorf("envgen: %s.%s: unexported field cannot be set", structName, field.Name())
+		}
+		return nil, nil
+	}
+	tag, err := g.parseTag(structName, field, structTag)
+	if err != nil || tag == nil {
+		return nil, err
+	}
+	if !field.Exported() && !tag.inline {
+		return nil, fmt.Errorf("envgen: %s.%s: unexported field cannot be set", structName, field.Name())
+	}
+	return tag, nil
+}
+
+// parseTag parses the struct tag in the same way as env.Loader, return nil if no tag set.
+func (g *generator) parseTag(structName string, field *types.Var, structTag reflect.StructTag) (*tagInfo, error) {
+	value, ok := structTag.Lookup(g.tagName)
+	if !ok {
+		// the anonymous struct without tag is inlined
+		if field.Embedded() && g.isNestedType(field.Type()) {
+			return &tagInfo{inline: true}, nil
+		}
+		return nil, nil
+	}
+	values := strings.Split(value, ",")
+	key, args := values[0], values[1:]
+	if key == "-" || (key == "" && !hasInlineOption(args)) {
+		return nil, nil
+	}
+	absolute := strings.HasPrefix(key, "/")
+	if absolute {
+		key = key[1:]
+		if key == "" {
+			return nil, fmt.Errorf("envgen: %s.%s: invalid key in tag '%s', absolute key cannot be empty", structName, field.Name(), structTag)
+		}
+	}
+	if strings.Contains(key, " ") {
+		return nil, fmt.Errorf("envgen: %s.%s: invalid key in tag '%s', cannot contain white space characters", structName, field.Name(), structTag)
+	}
+
+	tag := &tagInfo{key: key, absolute: absolute}
+	for _, arg := range args {
+		x := strings.SplitN(arg, "=", 2)
+		switch x[0] {
+		case "default":
+			if len(x) != 2 {
+				return nil, fmt.Errorf("envgen: %s.%s: cannot parse keyword 'default' from tag '%s'", structName, field.Name(), structTag)
+			}
+			tag.defVal = x[1]
+		case "secret":
+			if len(x) != 1 {
+				return nil, fmt.Errorf("envgen: %s.%s: invalid keyword 'secret' in tag '%s', it cannot have a value", structName, field.Name(), structTag)
+			}
+			tag.secret = true
+		case "noprefix":
+			if len(x) != 1 {
+				return nil, fmt.Errorf("envgen: %s.%s: invalid keyword 'noprefix' in tag '%s', it cannot have a value", structName, field.Name(), structTag)
+			}
+			tag.absolute = true
+		case "prefix":
+			if len(x) != 2 || !g.isNestedType(field.Type()) {
+				return nil, fmt.Errorf("envgen: %s.%s: invalid keyword 'prefix' in tag '%s'", structName, field.Name(), structTag)
+			}
+			tag.prefix = &x[1]
+		case "inline", "squash":
+			if len(x) != 1 || key != "" || !g.isNestedType(field.Type()) {
+				return nil, fmt.Errorf("envgen: %s.%s: invalid keyword '%s' in tag '%s'", structName, field.Name(), x[0], structTag)
+			}
+			tag.inline = true
+		default:
+			return nil, fmt.Errorf("envgen: %s.%s: tag option '%s' is not supported", structName, field.Name(), x[0])
+		}
+	}
+	return tag, nil
+}
+
+func hasInlineOption(args []string) bool {
+	for _, arg := range args {
+		if arg == "inline" || arg == "squash" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkKeys return error if two fields of the struct resolve to the same key with the default Getter.
+func (g *generator) checkKeys(named *types.Named, prefix string, keys map[string]string) error {
+	name := named.Obj().Name()
+	st := named.Underlying().(*types.Struct)
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag, err := g.fieldTag(name, field, reflect.StructTag(st.Tag(i)))
+		if err != nil {
+			return err
+		}
+		if tag == nil {
+			continue
+		}
+
+		t := field.Type()
+		if ptr, ok := t.(*types.Pointer); ok && isStruct(ptr.Elem()) && !isBuiltinParsed(ptr) {
+			t = ptr.Elem()
+		}
+		nested, _ := t.(*types.Named)
+		switch {
+		case tag.inline && nested != nil:
+			if err := g.checkKeys(nested, prefix, keys); err != nil {
+				return err
+			}
+		case g.isNestedStruct(t) && nested != nil:
+			if err := g.checkKeys(nested, mergeKey(prefix, tag.segment(), tag.absolute), keys); err != nil {
+				return err
+			}
+		default:
+			key := strings.ToUpper(mergeKey(prefix, tag.key, tag.absolute))
+			if other, ok := keys[key]; ok {
+				return fmt.Errorf("envgen: %s.%s: key '%s' is already used by '%s'", name, field.Name(), key, other)
+			}
+			keys[key] = name + "." + field.Name()
+		}
+	}
+	return nil
+}
+
+// mergeKey merge the prefix and key in the same way as the default Getter.
+func mergeKey(prefix string, key string, absolute bool) string {
+	if absolute {
+		prefix = ""
+	}
+	if prefix != "" && key != "" {
+		return prefix + "_" + key
+	}
+	return prefix + key
+}
+
+func (g *generator) genField(structName string, field *types.Var, tag *tagInfo) error {
+	w := &g.loaders
+	target := "c." + field.Name()
+	addr := "&" + target
+	t := field.Type()
+
+	// create a new object if nil pointer for struct-type
+	if ptr, ok := t.(*types.Pointer); ok && (tag.inline || isStruct(ptr.Elem()) && !isBuiltinParsed(ptr)) {
+		fmt.Fprintf(w, "if %s == nil {\n%s = new(%s)\n}\n", target, target, g.typeString(ptr.Elem()))
+		addr = target
+		target = "(*" + target + ")"
+		t = ptr.Elem()
+	}
+
+	if tag.inline || g.isNestedStruct(t) {
+		named, ok := t.(*types.Named)
+		if !ok || named.Obj().Pkg() != g.pkg {
+			return fmt.Errorf("envgen: %s.%s: nested struct must be a named type in package '%s'", structName, field.Name(), g.pkg.Name())
+		}
+		g.enqueue(named)
+		// the fields of inline struct are loaded into the key space of parent
+		prefix := "prefix"
+		if !tag.inline {
+			prefix = fmt.Sprintf("g.Merge(%s, %s)", tag.parent(), strconv.Quote(tag.segment()))
+		}
+		fmt.Fprintf(w, "if err := c.%s.LoadEnvWith(g, %s, override); err != nil {\nreturn err\n}\n", field.Name(), prefix)
+		return nil
+	}
+
+	isZero, err := g.zeroCheck(target, t)
+	if err != nil {
+		return fmt.Errorf("envgen: %s.%s: %w", structName, field.Name(), err)
+	}
+	setter, err := g.setter(t)
+	if err != nil {
+		return fmt.Errorf("envgen: %s.%s: %w", structName, field.Name(), err)
+	}
+
+	fmt.Fprintf(w, "if override || %s {\n", isZero)
+	fmt.Fprintf(w, "key := g.Merge(%s, %s)\n", tag.parent(), strconv.Quote(tag.key))
+	if tag.defVal != "" {
+		fmt.Fprintf(w, "value, found, err := g.Get(key)\n")
+		fmt.Fprintf(w, "if err != nil {\nreturn err\n}\n")
+		fmt.Fprintf(w, "if !found && %s {\nvalue = %s\n}\n", isZero, strconv.Quote(tag.defVal))
+	} else {
+		fmt.Fprintf(w, "value, _, err := g.Get(key)\n")
+		fmt.Fprintf(w, "if err != nil {\nreturn err\n}\n")
+	}
+	fmt.Fprintf(w, "if value != \"\" {\n")
+	fmt.Fprintf(w, "if envcore.IsEncrypted(value) {\nreturn &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}\n}\n")
+	fmt.Fprintf(w, "if err := %s(%s, value); err != nil {\n", setter, addr)
+	// the value of secret field is redacted as env.Loader
+	valueExpr, errExpr := "value", "err"
+	if tag.secret {
+		valueExpr, errExpr = "envcore.Redacted", "envcore.RedactError(err)"
+	}
+	fmt.Fprintf(w, "return &envcore.ParseError{KeyName: key, FieldName: %s, TypeName: %s, Value: %s, Err: %s}\n",
+		strconv.Quote(structName+"."+field.Name()), strconv.Quote(reflectString(t)), valueExpr, errExpr)
+	fmt.Fprintf(w, "}\n}\n}\n")
+	return nil
+}
+
+// zeroCheck return the expression that reports whether the target is zero as reflect.Value.IsZero.
+func (g *generator) zeroCheck(target string, t types.Type) (string, error) {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "!" + target, nil
+		case u.Info()&types.IsString != 0:
+			return target + " == \"\"", nil
+		case u.Info()&types.IsNumeric != 0:
+			return target + " == 0", nil
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface, *types.Signature, *types.Chan:
+		return target + " == nil", nil
+	}
+	if types.Comparable(t) {
+		return fmt.Sprintf("%s == (%s{})", target, g.typeString(t)), nil
+	}
+	// the struct is zero if all fields are zero
+	if st, ok := t.Underlying().(*types.Struct); ok {
+		var checks []string
+		for i := 0; i < st.NumFields(); i++ {
+			field := st.Field(i)
+			if !field.Exported() && field.Pkg() != g.pkg {
+				return "", fmt.Errorf("cannot check zero value of type '%s'", reflectString(t))
+			}
+			check, err := g.zeroCheck(target+"."+field.Name(), field.Type())
+			if err != nil {
+				return "", err
+			}
+			checks = append(checks, "("+check+")")
+		}
+		if len(checks) == 0 {
+			return "true", nil
+		}
+		return strings.Join(checks, " && "), nil
+	}
+	return "", fmt.Errorf("cannot check zero value of type '%s'", reflectString(t))
+}
+
+// setter return the name of function that set value to the type, the function is generated if not exists.
+func (g *generator) setter(t types.Type) (string, error) {
+	key := g.typeString(t)
+	if name, ok := g.setters[key]; ok {
+		return name, nil
+	}
+	name := fmt.Sprintf("envgenSet%d", len(g.setters))
+	g.setters[key] = name
+
+	var body bytes.Buffer
+	if err := g.genSetter(&body, t); err != nil {
+		delete(g.setters, key)
+		return "", err
+	}
+	fmt.Fprintf(&g.funcs, "func %s(dst *%s, value string) error {\n", name, key)
+	g.funcs.Write(body.Bytes())
+	fmt.Fprintf(&g.funcs, "}\n\n")
+	return name, nil
+}
+
+// genSetter generates the body of setter function in the same way as env.Loader.setField.
+func (g *generator) genSetter(w *bytes.Buffer, t types.Type) error {
+	if ptr, ok := t.(*types.Pointer); ok {
+		if isBuiltinParsed(ptr) {
+			return fmt.Errorf("type '%s' is not supported", reflectString(t))
+		}
+		elem, err := g.setter(ptr.Elem())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "if *dst == nil {\n*dst = new(%s)\n}\n", g.typeString(ptr.Elem()))
+		fmt.Fprintf(w, "return %s(*dst, value)\n", elem)
+		return nil
+	}
+
+	if isBuiltinParsed(t) {
+		return fmt.Errorf("type '%s' is not supported", reflectString(t))
+	}
+
+	if methods := setterMethods(t); len(methods) != 0 {
+		// the errors are joined as fmt.Errorf("%v", errs) of env.Loader without fmt
+		fmt.Fprintf(w, "var msgs []string\n")
+		for _, method := range methods {
+			arg := "value"
+			if method != "Set" {
+				arg = "[]byte(value)"
+			}
+			fmt.Fprintf(w, "if err := dst.%s(%s); err == nil {\nreturn nil\n} else {\nmsgs = append(msgs, err.Error())\n}\n", method, arg)
+		}
+		fmt.Fprintf(w, "return %s.New(\"[\" + %s.Join(msgs, \" \") + \"]\")\n", g.useStd("errors"), g.useStd("strings"))
+		return nil
+	}
+
+	typ := g.typeString(t)
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return g.genBasicSetter(w, t, u)
+	case *types.Slice:
+		if b, ok := u.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Uint8 {
+			fmt.Fprintf(w, "*dst = %s(value)\nreturn nil\n", typ)
+			return nil
+		}
+		elem, err := g.setter(u.Elem())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "parts := %s.Split(value, \" \")\n", g.useStd("strings"))
+		fmt.Fprintf(w, "sl := make(%s, len(parts))\n", typ)
+		fmt.Fprintf(w, "for i, part := range parts {\nif err := %s(&sl[i], part); err != nil {\nreturn err\n}\n}\n", elem)
+		fmt.Fprintf(w, "*dst = sl\nreturn nil\n")
+		return nil
+	case *types.Array:
+		elem, err := g.setter(u.Elem())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "parts := %s.Split(value, \" \")\n", g.useStd("strings"))
+		fmt.Fprintf(w, "if len(parts) != %d {\nreturn %s.New(%s)\n}\n", u.Len(), g.useStd("errors"), strconv.Quote("not enough elements for set "+reflectString(t)))
+		fmt.Fprintf(w, "for i, part := range parts {\nif err := %s(&dst[i], part); err != nil {\nreturn err\n}\n}\n", elem)
+		fmt.Fprintf(w, "return nil\n")
+		return nil
+	case *types.Map:
+		key, err := g.setter(u.Key())
+		if err != nil {
+			return err
+		}
+		elem, err := g.setter(u.Elem())
+		if err != nil {
+			return err
+		}
+		strs := g.useStd("strings")
+		fmt.Fprintf(w, "mp := make(%s)\n", typ)
+		fmt.Fprintf(w, "for _, pair := range %s.Split(value, \" \") {\n", strs)
+		fmt.Fprintf(w, "kv := %s.Split(pair, \":\")\n", strs)
+		fmt.Fprintf(w, "if len(kv) < 2 {\nreturn %s.New(\"invalid map items\")\n}\n", g.useStd("errors"))
+		fmt.Fprintf(w, "var k %s\nif err := %s(&k, kv[0]); err != nil {\nreturn err\n}\n", g.typeString(u.Key()), key)
+		fmt.Fprintf(w, "var v %s\nif err := %s(&v, %s.Join(kv[1:], \":\")); err != nil {\nreturn err\n}\n", g.typeString(u.Elem()), elem, strs)
+		fmt.Fprintf(w, "mp[k] = v\n}\n")
+		fmt.Fprintf(w, "*dst = mp\nreturn nil\n")
+		return nil
+	}
+	return fmt.Errorf("type '%s' is not supported", reflectString(t))
+}
+
+func (g *generator) genBasicSetter(w *bytes.Buffer, t types.Type, u *types.Basic) error {
+	typ := g.typeString(t)
+	conv := g.useStd("strconv")
+	var parse string
+	switch u.Kind() {
+	case types.String:
+		fmt.Fprintf(w, "*dst = %s(value)\nreturn nil\n", typ)
+		return nil
+	case types.Int, types.Int8, types.Int16, types.Int32, types.Int64:
+		if reflectString(t) == "time.Duration" {
+			parse = fmt.Sprintf("%s.ParseDuration(value)", g.useStd("time"))
+		} else {
+			parse = fmt.Sprintf("%s.ParseInt(value, 0, %s)", conv, bitSize(conv, u.Kind()))
+		}
+	case types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+		parse = fmt.Sprintf("%s.ParseUint(value, 0, %s)", conv, bitSize(conv, u.Kind()))
+	case types.Float32, types.Float64:
+		parse = fmt.Sprintf("%s.ParseFloat(value, %s)", conv, bitSize(conv, u.Kind()))
+	case types.Bool:
+		parse = fmt.Sprintf("%s.ParseBool(value)", conv)
+	default:
+		return fmt.Errorf("type '%s' is not supported", reflectString(t))
+	}
+	fmt.Fprintf(w, "v, err := %s\nif err != nil {\nreturn err\n}\n", parse)
+	fmt.Fprintf(w, "*dst = %s(v)\nreturn nil\n", typ)
+	return nil
+}
+
+func bitSize(conv string, kind types.BasicKind) string {
+	switch kind {
+	case types.Int8, types.Uint8:
+		return "8"
+	case types.Int16, types.Uint16:
+		return "16"
+	case types.Int32, types.Uint32, types.Float32:
+		return "32"
+	case types.Int64, types.Uint64, types.Float64:
+		return "64"
+	default:
+		return conv + ".IntSize"
+	}
+}
+
+// isNestedStruct reports whether the fields of struct should be loaded recursively as env.Loader.
+func (g *generator) isNestedStruct(t types.Type) bool {
+	return isStruct(t) && !isBuiltinParsed(t) && !isOptional(t) && len(setterMethods(t)) == 0
+}
+
+// isOptional reports whether the type is env.Optional, it's not supported.
+func isOptional(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Origin().Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == envPkgPath && obj.Name() == "Optional"
+}
+
+// isNestedType reports whether the struct or pointer to struct type should be loaded recursively.
+func (g *generator) isNestedType(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok && !isBuiltinParsed(ptr) {
+		t = ptr.Elem()
+	}
+	return g.isNestedStruct(t)
+}
+
+func isPointer(t types.Type) bool {
+	_, ok := t.(*types.Pointer)
+	return ok
+}
+
+func isStruct(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
+
+// builtinParsed is the types that parsed by the builtin parsers of env.
+var builtinParsed = map[string]bool{
+	"net.IP":             true,
+	"net.IPNet":          true,
+	"net.HardwareAddr":   true,
+	"net/netip.Addr":     true,
+	"net/netip.Prefix":   true,
+	"net/netip.AddrPort": true,
+}
+
+func isBuiltinParsed(t types.Type) bool {
+	return builtinParsed[types.TypeString(t, func(p *types.Package) string { return p.Path() })]
+}
+
+var (
+	errorType  = types.Universe.Lookup("error").Type()
+	stringType = types.Typ[types.String]
+	bytesType  = types.NewSlice(types.Typ[types.Byte])
+)
+
+// setterIfaces in the order of env.getSetters.
+var setterIfaces = []struct {
+	method string
+	iface  *types.Interface
+}{
+	{"Set", newIface("Set", stringType)},
+	{"UnmarshalText", newIface("UnmarshalText", bytesType)},
+	{"UnmarshalBinary", newIface("UnmarshalBinary", bytesType)},
+}
+
+func newIface(method string, param types.Type) *types.Interface {
+	sig := types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "v", param)),
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", errorType)), false)
+	fn := types.NewFunc(token.NoPos, nil, method, sig)
+	return types.NewInterfaceType([]*types.Func{fn}, nil).Complete()
+}
+
+// setterMethods return the methods of *t that implements env.Setter,
+// encoding.TextUnmarshaler and encoding.BinaryUnmarshaler.
+func setterMethods(t types.Type) []string {
+	var methods []string
+	for _, s := range setterIfaces {
+		if types.Implements(types.NewPointer(t), s.iface) {
+			methods = append(methods, s.method)
+		}
+	}
+	return methods
+}
@@ -0,0 +1,63 @@
+package envgen_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env/internal/envgen"
+)
+
+func TestGenerate_Unsupported(t *testing.T) {
+	cases := map[string]string{
+		"Layout":     "envgen: Layout.Time: tag option 'layout' is not supported",
+		"IP":         "envgen: IP.IP: type 'net.IP' is not supported",
+		"Chan":       "envgen: Chan.C: type 'chan int' is not supported",
+		"Anonymous":  "envgen: Anonymous.Inner: nested struct must be a named type in package 'unsupported'",
+		"Conflict":   "envgen: Conflict.Name: key 'NAME' is already used by 'Inner.Name'",
+		"Unexported": "envgen: Unexported.name: unexported field cannot be set",
+		"Optional":   "envgen: Optional.Port: type 'env.Optional[int]' is not supported",
+		"NotStruct":  "envgen: type 'NotStruct' is not a struct",
+		"Missing":    "envgen: type 'Missing' is not found in package 'unsupported'",
+		"Secret":     "envgen: Secret.Password: invalid keyword 'secret' in tag 'env:\"PASSWORD,secret=true\"', it cannot have a value",
+	}
+	for name, msg := range cases {
+		_, err := envgen.Generate(envgen.Config{Dir: "testdata/unsupported", Types: []string{name}})
+		require.NotNil(t, err, name)
+		require.Equal(t, msg, err.Error())
+	}
+}
+
+func TestGenerate_Imports(t *testing.T) {
+	dir := "testdata/imports"
+	src, err := envgen.Generate(envgen.Config{Dir: dir, Types: []string{"Imports"}})
+	require.Nil(t, err)
+	require.Contains(t, string(src), "\t\"github.com/yu31/env/envcore\"\n")
+	require.NotContains(t, string(src), "\t\"github.com/yu31/env\"\n")
+	require.Contains(t, string(src), "\tstrings2 \"strings\"\n")
+	require.Contains(t, string(src), "\t\"github.com/yu31/env/internal/envgen/testdata/imports/a/kind\"\n")
+	require.Contains(t, string(src), "\tkind2 \"github.com/yu31/env/internal/envgen/testdata/imports/b/kind\"\n")
+	require.Contains(t, string(src), "\tlevel \"github.com/yu31/env/internal/envgen/testdata/imports/level/v2\"\n")
+
+	// the generated code must compile with the package.
+	fset := token.NewFileSet()
+	files := []*ast.File{}
+	for _, name := range []string{"imports.go", "imports_env.go"} {
+		var content interface{}
+		if name == "imports_env.go" {
+			content = src
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), content, 0)
+		require.Nil(t, err)
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("github.com/yu31/env/internal/envgen/testdata/imports", fset, files, nil)
+	require.Nil(t, err)
+}
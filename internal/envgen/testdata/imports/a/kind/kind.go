@@ -0,0 +1,3 @@
+package kind
+
+type Kind string
@@ -0,0 +1,17 @@
+package imports
+
+import (
+	akind "github.com/yu31/env/internal/envgen/testdata/imports/a/kind"
+	bkind "github.com/yu31/env/internal/envgen/testdata/imports/b/kind"
+	"github.com/yu31/env/internal/envgen/testdata/imports/level/v2"
+)
+
+// strings conflicts with the standard package used by the generated code.
+var strings = []string{}
+
+type Imports struct {
+	Level level.Level `env:"LEVEL"`
+	A     akind.Kind  `env:"A"`
+	B     bkind.Kind  `env:"B"`
+	Names []string    `env:"NAMES"`
+}
@@ -0,0 +1,48 @@
+package unsupported
+
+import (
+	"net"
+
+	"github.com/yu31/env"
+)
+
+type Layout struct {
+	Time string `env:"TIME,layout=2006-01-02"`
+}
+
+type IP struct {
+	IP net.IP `env:"IP"`
+}
+
+type Chan struct {
+	C chan int `env:"C"`
+}
+
+type Anonymous struct {
+	Inner struct {
+		Name string `env:"NAME"`
+	} `env:"INNER"`
+}
+
+type NotStruct int
+
+type Conflict struct {
+	Inner
+	Name string `env:"NAME"`
+}
+
+type Inner struct {
+	Name string `env:"NAME"`
+}
+
+type Unexported struct {
+	name string `env:"NAME"`
+}
+
+type Optional struct {
+	Port env.Optional[int] `env:"PORT"`
+}
+
+type Secret struct {
+	Password string `env:"PASSWORD,secret=true"`
+}
@@ -0,0 +1,105 @@
+// Package gentest is the test harness of envgen, it checks the generated loaders
+// populate the structs in the same way as env.Loader.
+package gentest
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+//go:generate go run ../../cmd/envgen -type Config
+
+// Config covers the types and tag options supported by envgen.
+type Config struct {
+	String   string            `env:"STRING,default=hello"`
+	Int      int               `env:"INT,default=1"`
+	Int8     int8              `env:"INT8"`
+	Int64    int64             `env:"INT64"`
+	Uint     uint              `env:"UINT"`
+	Uint16   uint16            `env:"UINT16,default=0x10"`
+	Float32  float32           `env:"FLOAT32"`
+	Float64  float64           `env:"FLOAT64,default=1.5"`
+	Bool     bool              `env:"BOOL"`
+	Duration time.Duration     `env:"DURATION,default=1m"`
+	Time     time.Time         `env:"TIME"`
+	URL      url.URL           `env:"URL"`
+	Password string            `env:"PASSWORD,secret"`
+	Pin      int               `env:"PIN,secret"`
+	Bytes    []byte            `env:"BYTES"`
+	IntPtr   *int              `env:"INT_PTR"`
+	Strings  []string          `env:"STRINGS,default=a b"`
+	Floats   []float64         `env:"FLOATS"`
+	Array    [2]int            `env:"ARRAY"`
+	Map      map[string]int    `env:"MAP"`
+	MapPtr   map[string]*Level `env:"MAP_PTR"`
+	Level    Level             `env:"LEVEL,default=info"`
+	LevelPtr *Level            `env:"LEVEL_PTR"`
+	Names    Names             `env:"NAMES"`
+	Server   Server            `env:"SERVER"`
+	DB       *Database         `env:"DB"`
+	Legacy   Database          `env:"LEGACY,noprefix"`
+	Replica  Database          `env:"REPLICA,prefix=RO"`
+	Home     string            `env:"/HOME"`
+	Common
+	meta
+	Limits  Limits `env:",inline"`
+	Skipped string `env:"-"`
+	NoTag   string
+	private string
+}
+
+// Server is a nested struct.
+type Server struct {
+	Host    string        `env:"HOST,default=localhost"`
+	Port    uint16        `env:"PORT,default=8080"`
+	Timeout time.Duration `env:"TIMEOUT"`
+}
+
+// Database is a nested struct referenced by pointer.
+type Database struct {
+	DSN    string `env:"DSN"`
+	Server Server `env:"SERVER"`
+}
+
+// Common is embedded and flattened into Config.
+type Common struct {
+	Region string `env:"REGION"`
+}
+
+// meta is unexported but its fields are loaded.
+type meta struct {
+	Version string `env:"VERSION,default=v1"`
+}
+
+// Limits is inlined into Config.
+type Limits struct {
+	MaxConns int `env:"MAX_CONNS"`
+}
+
+// Level implements env.Setter.
+type Level int
+
+// Set implements env.Setter.
+func (l *Level) Set(value string) error {
+	switch value {
+	case "debug":
+		*l = 1
+	case "info":
+		*l = 2
+	default:
+		return &url.Error{Op: "parse level", URL: value, Err: url.EscapeError(value)}
+	}
+	return nil
+}
+
+// Names implements encoding.TextUnmarshaler.
+type Names struct {
+	List []string
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (n *Names) UnmarshalText(text []byte) error {
+	n.List = strings.Split(string(text), ";")
+	return nil
+}
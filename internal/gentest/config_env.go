@@ -0,0 +1,839 @@
+// Code generated by envgen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yu31/env/envcore"
+)
+
+// LoadEnv populates c with the value from g in the same way as env.Loader.Load.
+func (c *Config) LoadEnv(g envcore.Getter) error {
+	return c.LoadEnvWith(g, "", false)
+}
+
+// LoadEnvWith is like LoadEnv but with prefix and override as env.WithPrefix and env.WithOverride.
+func (c *Config) LoadEnvWith(g envcore.Getter, prefix string, override bool) error {
+	if override || c.String == "" {
+		key := g.Merge(prefix, "STRING")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.String == "" {
+			value = "hello"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet0(&c.String, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.String", TypeName: "string", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Int == 0 {
+		key := g.Merge(prefix, "INT")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.Int == 0 {
+			value = "1"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet1(&c.Int, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Int", TypeName: "int", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Int8 == 0 {
+		key := g.Merge(prefix, "INT8")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet2(&c.Int8, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Int8", TypeName: "int8", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Int64 == 0 {
+		key := g.Merge(prefix, "INT64")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet3(&c.Int64, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Int64", TypeName: "int64", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Uint == 0 {
+		key := g.Merge(prefix, "UINT")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet4(&c.Uint, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Uint", TypeName: "uint", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Uint16 == 0 {
+		key := g.Merge(prefix, "UINT16")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.Uint16 == 0 {
+			value = "0x10"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet5(&c.Uint16, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Uint16", TypeName: "uint16", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Float32 == 0 {
+		key := g.Merge(prefix, "FLOAT32")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet6(&c.Float32, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Float32", TypeName: "float32", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Float64 == 0 {
+		key := g.Merge(prefix, "FLOAT64")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.Float64 == 0 {
+			value = "1.5"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet7(&c.Float64, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Float64", TypeName: "float64", Value: value, Err: err}
+			}
+		}
+	}
+	if override || !c.Bool {
+		key := g.Merge(prefix, "BOOL")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet8(&c.Bool, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Bool", TypeName: "bool", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Duration == 0 {
+		key := g.Merge(prefix, "DURATION")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.Duration == 0 {
+			value = "1m"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet9(&c.Duration, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Duration", TypeName: "time.Duration", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Time == (time.Time{}) {
+		key := g.Merge(prefix, "TIME")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet10(&c.Time, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Time", TypeName: "time.Time", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.URL == (url.URL{}) {
+		key := g.Merge(prefix, "URL")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet11(&c.URL, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.URL", TypeName: "url.URL", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Password == "" {
+		key := g.Merge(prefix, "PASSWORD")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet0(&c.Password, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Password", TypeName: "string", Value: envcore.Redacted, Err: envcore.RedactError(err)}
+			}
+		}
+	}
+	if override || c.Pin == 0 {
+		key := g.Merge(prefix, "PIN")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet1(&c.Pin, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Pin", TypeName: "int", Value: envcore.Redacted, Err: envcore.RedactError(err)}
+			}
+		}
+	}
+	if override || c.Bytes == nil {
+		key := g.Merge(prefix, "BYTES")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet12(&c.Bytes, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Bytes", TypeName: "[]byte", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.IntPtr == nil {
+		key := g.Merge(prefix, "INT_PTR")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet13(&c.IntPtr, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.IntPtr", TypeName: "*int", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Strings == nil {
+		key := g.Merge(prefix, "STRINGS")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.Strings == nil {
+			value = "a b"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet14(&c.Strings, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Strings", TypeName: "[]string", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Floats == nil {
+		key := g.Merge(prefix, "FLOATS")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet15(&c.Floats, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Floats", TypeName: "[]float64", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Array == ([2]int{}) {
+		key := g.Merge(prefix, "ARRAY")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet16(&c.Array, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Array", TypeName: "[2]int", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Map == nil {
+		key := g.Merge(prefix, "MAP")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet17(&c.Map, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Map", TypeName: "map[string]int", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.MapPtr == nil {
+		key := g.Merge(prefix, "MAP_PTR")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet18(&c.MapPtr, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.MapPtr", TypeName: "map[string]*gentest.Level", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Level == 0 {
+		key := g.Merge(prefix, "LEVEL")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.Level == 0 {
+			value = "info"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet20(&c.Level, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Level", TypeName: "gentest.Level", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.LevelPtr == nil {
+		key := g.Merge(prefix, "LEVEL_PTR")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet19(&c.LevelPtr, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.LevelPtr", TypeName: "*gentest.Level", Value: value, Err: err}
+			}
+		}
+	}
+	if override || (c.Names.List == nil) {
+		key := g.Merge(prefix, "NAMES")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet21(&c.Names, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Names", TypeName: "gentest.Names", Value: value, Err: err}
+			}
+		}
+	}
+	if err := c.Server.LoadEnvWith(g, g.Merge(prefix, "SERVER"), override); err != nil {
+		return err
+	}
+	if c.DB == nil {
+		c.DB = new(Database)
+	}
+	if err := c.DB.LoadEnvWith(g, g.Merge(prefix, "DB"), override); err != nil {
+		return err
+	}
+	if err := c.Legacy.LoadEnvWith(g, g.Merge("", "LEGACY"), override); err != nil {
+		return err
+	}
+	if err := c.Replica.LoadEnvWith(g, g.Merge(prefix, "RO"), override); err != nil {
+		return err
+	}
+	if override || c.Home == "" {
+		key := g.Merge("", "HOME")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet0(&c.Home, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Config.Home", TypeName: "string", Value: value, Err: err}
+			}
+		}
+	}
+	if err := c.Common.LoadEnvWith(g, prefix, override); err != nil {
+		return err
+	}
+	if err := c.meta.LoadEnvWith(g, prefix, override); err != nil {
+		return err
+	}
+	if err := c.Limits.LoadEnvWith(g, prefix, override); err != nil {
+		return err
+	}
+	return nil
+}
+
+// LoadEnv populates c with the value from g in the same way as env.Loader.Load.
+func (c *Server) LoadEnv(g envcore.Getter) error {
+	return c.LoadEnvWith(g, "", false)
+}
+
+// LoadEnvWith is like LoadEnv but with prefix and override as env.WithPrefix and env.WithOverride.
+func (c *Server) LoadEnvWith(g envcore.Getter, prefix string, override bool) error {
+	if override || c.Host == "" {
+		key := g.Merge(prefix, "HOST")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.Host == "" {
+			value = "localhost"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet0(&c.Host, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Server.Host", TypeName: "string", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Port == 0 {
+		key := g.Merge(prefix, "PORT")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.Port == 0 {
+			value = "8080"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet5(&c.Port, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Server.Port", TypeName: "uint16", Value: value, Err: err}
+			}
+		}
+	}
+	if override || c.Timeout == 0 {
+		key := g.Merge(prefix, "TIMEOUT")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet9(&c.Timeout, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Server.Timeout", TypeName: "time.Duration", Value: value, Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// LoadEnv populates c with the value from g in the same way as env.Loader.Load.
+func (c *Database) LoadEnv(g envcore.Getter) error {
+	return c.LoadEnvWith(g, "", false)
+}
+
+// LoadEnvWith is like LoadEnv but with prefix and override as env.WithPrefix and env.WithOverride.
+func (c *Database) LoadEnvWith(g envcore.Getter, prefix string, override bool) error {
+	if override || c.DSN == "" {
+		key := g.Merge(prefix, "DSN")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet0(&c.DSN, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Database.DSN", TypeName: "string", Value: value, Err: err}
+			}
+		}
+	}
+	if err := c.Server.LoadEnvWith(g, g.Merge(prefix, "SERVER"), override); err != nil {
+		return err
+	}
+	return nil
+}
+
+// LoadEnv populates c with the value from g in the same way as env.Loader.Load.
+func (c *Common) LoadEnv(g envcore.Getter) error {
+	return c.LoadEnvWith(g, "", false)
+}
+
+// LoadEnvWith is like LoadEnv but with prefix and override as env.WithPrefix and env.WithOverride.
+func (c *Common) LoadEnvWith(g envcore.Getter, prefix string, override bool) error {
+	if override || c.Region == "" {
+		key := g.Merge(prefix, "REGION")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet0(&c.Region, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Common.Region", TypeName: "string", Value: value, Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// LoadEnv populates c with the value from g in the same way as env.Loader.Load.
+func (c *meta) LoadEnv(g envcore.Getter) error {
+	return c.LoadEnvWith(g, "", false)
+}
+
+// LoadEnvWith is like LoadEnv but with prefix and override as env.WithPrefix and env.WithOverride.
+func (c *meta) LoadEnvWith(g envcore.Getter, prefix string, override bool) error {
+	if override || c.Version == "" {
+		key := g.Merge(prefix, "VERSION")
+		value, found, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if !found && c.Version == "" {
+			value = "v1"
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet0(&c.Version, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "meta.Version", TypeName: "string", Value: value, Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// LoadEnv populates c with the value from g in the same way as env.Loader.Load.
+func (c *Limits) LoadEnv(g envcore.Getter) error {
+	return c.LoadEnvWith(g, "", false)
+}
+
+// LoadEnvWith is like LoadEnv but with prefix and override as env.WithPrefix and env.WithOverride.
+func (c *Limits) LoadEnvWith(g envcore.Getter, prefix string, override bool) error {
+	if override || c.MaxConns == 0 {
+		key := g.Merge(prefix, "MAX_CONNS")
+		value, _, err := g.Get(key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			if envcore.IsEncrypted(value) {
+				return &envcore.DecryptError{KeyName: key, Err: envcore.ErrNoDecryptionKey}
+			}
+			if err := envgenSet1(&c.MaxConns, value); err != nil {
+				return &envcore.ParseError{KeyName: key, FieldName: "Limits.MaxConns", TypeName: "int", Value: value, Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+func envgenSet0(dst *string, value string) error {
+	*dst = string(value)
+	return nil
+}
+
+func envgenSet1(dst *int, value string) error {
+	v, err := strconv.ParseInt(value, 0, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	*dst = int(v)
+	return nil
+}
+
+func envgenSet2(dst *int8, value string) error {
+	v, err := strconv.ParseInt(value, 0, 8)
+	if err != nil {
+		return err
+	}
+	*dst = int8(v)
+	return nil
+}
+
+func envgenSet3(dst *int64, value string) error {
+	v, err := strconv.ParseInt(value, 0, 64)
+	if err != nil {
+		return err
+	}
+	*dst = int64(v)
+	return nil
+}
+
+func envgenSet4(dst *uint, value string) error {
+	v, err := strconv.ParseUint(value, 0, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	*dst = uint(v)
+	return nil
+}
+
+func envgenSet5(dst *uint16, value string) error {
+	v, err := strconv.ParseUint(value, 0, 16)
+	if err != nil {
+		return err
+	}
+	*dst = uint16(v)
+	return nil
+}
+
+func envgenSet6(dst *float32, value string) error {
+	v, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return err
+	}
+	*dst = float32(v)
+	return nil
+}
+
+func envgenSet7(dst *float64, value string) error {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	*dst = float64(v)
+	return nil
+}
+
+func envgenSet8(dst *bool, value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*dst = bool(v)
+	return nil
+}
+
+func envgenSet9(dst *time.Duration, value string) error {
+	v, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*dst = time.Duration(v)
+	return nil
+}
+
+func envgenSet10(dst *time.Time, value string) error {
+	var msgs []string
+	if err := dst.UnmarshalText([]byte(value)); err == nil {
+		return nil
+	} else {
+		msgs = append(msgs, err.Error())
+	}
+	if err := dst.UnmarshalBinary([]byte(value)); err == nil {
+		return nil
+	} else {
+		msgs = append(msgs, err.Error())
+	}
+	return errors.New("[" + strings.Join(msgs, " ") + "]")
+}
+
+func envgenSet11(dst *url.URL, value string) error {
+	var msgs []string
+	if err := dst.UnmarshalBinary([]byte(value)); err == nil {
+		return nil
+	} else {
+		msgs = append(msgs, err.Error())
+	}
+	return errors.New("[" + strings.Join(msgs, " ") + "]")
+}
+
+func envgenSet12(dst *[]byte, value string) error {
+	*dst = []byte(value)
+	return nil
+}
+
+func envgenSet13(dst **int, value string) error {
+	if *dst == nil {
+		*dst = new(int)
+	}
+	return envgenSet1(*dst, value)
+}
+
+func envgenSet14(dst *[]string, value string) error {
+	parts := strings.Split(value, " ")
+	sl := make([]string, len(parts))
+	for i, part := range parts {
+		if err := envgenSet0(&sl[i], part); err != nil {
+			return err
+		}
+	}
+	*dst = sl
+	return nil
+}
+
+func envgenSet15(dst *[]float64, value string) error {
+	parts := strings.Split(value, " ")
+	sl := make([]float64, len(parts))
+	for i, part := range parts {
+		if err := envgenSet7(&sl[i], part); err != nil {
+			return err
+		}
+	}
+	*dst = sl
+	return nil
+}
+
+func envgenSet16(dst *[2]int, value string) error {
+	parts := strings.Split(value, " ")
+	if len(parts) != 2 {
+		return errors.New("not enough elements for set [2]int")
+	}
+	for i, part := range parts {
+		if err := envgenSet1(&dst[i], part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func envgenSet17(dst *map[string]int, value string) error {
+	mp := make(map[string]int)
+	for _, pair := range strings.Split(value, " ") {
+		kv := strings.Split(pair, ":")
+		if len(kv) < 2 {
+			return errors.New("invalid map items")
+		}
+		var k string
+		if err := envgenSet0(&k, kv[0]); err != nil {
+			return err
+		}
+		var v int
+		if err := envgenSet1(&v, strings.Join(kv[1:], ":")); err != nil {
+			return err
+		}
+		mp[k] = v
+	}
+	*dst = mp
+	return nil
+}
+
+func envgenSet20(dst *Level, value string) error {
+	var msgs []string
+	if err := dst.Set(value); err == nil {
+		return nil
+	} else {
+		msgs = append(msgs, err.Error())
+	}
+	return errors.New("[" + strings.Join(msgs, " ") + "]")
+}
+
+func envgenSet19(dst **Level, value string) error {
+	if *dst == nil {
+		*dst = new(Level)
+	}
+	return envgenSet20(*dst, value)
+}
+
+func envgenSet18(dst *map[string]*Level, value string) error {
+	mp := make(map[string]*Level)
+	for _, pair := range strings.Split(value, " ") {
+		kv := strings.Split(pair, ":")
+		if len(kv) < 2 {
+			return errors.New("invalid map items")
+		}
+		var k string
+		if err := envgenSet0(&k, kv[0]); err != nil {
+			return err
+		}
+		var v *Level
+		if err := envgenSet19(&v, strings.Join(kv[1:], ":")); err != nil {
+			return err
+		}
+		mp[k] = v
+	}
+	*dst = mp
+	return nil
+}
+
+func envgenSet21(dst *Names, value string) error {
+	var msgs []string
+	if err := dst.UnmarshalText([]byte(value)); err == nil {
+		return nil
+	} else {
+		msgs = append(msgs, err.Error())
+	}
+	return errors.New("[" + strings.Join(msgs, " ") + "]")
+}
@@ -0,0 +1,138 @@
+package gentest_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/internal/envgen"
+	"github.com/yu31/env/internal/gentest"
+)
+
+func TestGenerated(t *testing.T) {
+	src, err := envgen.Generate(envgen.Config{Dir: ".", Types: []string{"Config"}})
+	require.Nil(t, err, "%+v", err)
+
+	data, err := os.ReadFile("config_env.go")
+	require.Nil(t, err)
+	require.Equal(t, string(data), string(src), "the generated file is outdated, run go generate")
+}
+
+var envSets = map[string]map[string]string{
+	"empty": {},
+	"full": {
+		"STRING":            "world",
+		"INT":               "-0x10",
+		"INT8":              "-8",
+		"INT64":             "1234567890123",
+		"UINT":              "42",
+		"UINT16":            "65535",
+		"FLOAT32":           "1.25",
+		"FLOAT64":           "-2.5e3",
+		"BOOL":              "true",
+		"DURATION":          "1h30m",
+		"TIME":              "2023-04-05T06:07:08Z",
+		"URL":               "https://example.com:8443/path?q=1",
+		"PASSWORD":          "secret",
+		"PIN":               "1234",
+		"BYTES":             "raw bytes",
+		"INT_PTR":           "7",
+		"STRINGS":           "x y z",
+		"FLOATS":            "1 2.5",
+		"ARRAY":             "3 4",
+		"MAP":               "a:1 b:2",
+		"MAP_PTR":           "x:debug",
+		"LEVEL":             "debug",
+		"LEVEL_PTR":         "info",
+		"NAMES":             "rob;ken",
+		"SERVER_HOST":       "0.0.0.0",
+		"SERVER_PORT":       "9090",
+		"SERVER_TIMEOUT":    "5s",
+		"DB_DSN":            "postgres://db",
+		"DB_SERVER_HOST":    "db.local",
+		"DB_SERVER_PORT":    "5432",
+		"DB_SERVER_TIMEOUT": "1s",
+		"REGION":            "eu",
+		"VERSION":           "v2",
+		"MAX_CONNS":         "10",
+		"LEGACY_DSN":        "legacy",
+		"RO_DSN":            "replica",
+		"RO_SERVER_PORT":    "6543",
+		"HOME":              "/root",
+		"SKIPPED":           "skipped",
+	},
+	"empty value": {
+		"STRING":      "",
+		"INT":         "",
+		"SERVER_HOST": "",
+	},
+	"reference": {
+		"STRING":      "ref+env://HOME",
+		"SERVER_HOST": "ref+file:///etc/hostname",
+	},
+	"invalid encrypted": {
+		"STRING":   "plain",
+		"PASSWORD": "enc:v1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+	},
+	"invalid encrypted int": {"INT": "enc:v1:AAAA"},
+	"invalid secret":        {"PIN": "hunter2"},
+	"invalid int":           {"INT8": "300"},
+	"invalid duration":      {"SERVER_TIMEOUT": "5"},
+	"invalid level":         {"LEVEL_PTR": "trace"},
+	"invalid array":         {"ARRAY": "1 2 3"},
+	"invalid map":           {"MAP": "a"},
+	"invalid nested":        {"DB_SERVER_PORT": "-1"},
+	"invalid time":          {"TIME": "now"},
+}
+
+func TestLoadEnv(t *testing.T) {
+	for name, values := range envSets {
+		t.Run(name, func(t *testing.T) {
+			g := env.MapGetter(values)
+
+			var want gentest.Config
+			wantErr := env.New(env.WithGetter(g)).Load(&want)
+
+			var got gentest.Config
+			gotErr := got.LoadEnv(g)
+
+			if strings.HasPrefix(name, "invalid") {
+				require.NotNil(t, gotErr)
+			}
+			require.Equal(t, wantErr, gotErr)
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestLoadEnvWith(t *testing.T) {
+	values := map[string]string{
+		"APP_STRING":      "world",
+		"APP_INT":         "2",
+		"APP_SERVER_PORT": "9090",
+		"APP_DB_DSN":      "postgres://db",
+		"LEGACY_DSN":      "legacy",
+		"APP_LEGACY_DSN":  "wrong",
+		"APP_RO_DSN":      "replica",
+		"HOME":            "/root",
+	}
+	g := env.MapGetter(values)
+
+	for _, override := range []bool{false, true} {
+		want := gentest.Config{String: "preset", Int: 1, Server: gentest.Server{Host: "preset"}}
+		wantErr := env.New(env.WithGetter(g), env.WithPrefix("APP"), env.WithOverride(override)).Load(&want)
+		require.Nil(t, wantErr)
+
+		got := gentest.Config{String: "preset", Int: 1, Server: gentest.Server{Host: "preset"}}
+		gotErr := got.LoadEnvWith(g, "APP", override)
+		require.Nil(t, gotErr)
+
+		require.Equal(t, want, got)
+		require.Equal(t, "legacy", got.Legacy.DSN)
+		require.Equal(t, "replica", got.Replica.DSN)
+		require.Equal(t, "/root", got.Home)
+	}
+}
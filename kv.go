@@ -0,0 +1,164 @@
+package env
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KVConfig configures the Getter created by NewKVGetter.
+type KVConfig struct {
+	// BaseURL is prepended to the path of key, e.g. "http://127.0.0.1:8500/v1/kv/".
+	BaseURL string
+	// Separator joins the prefix and key by Merge instead of '_', default is "/".
+	// The key "MAX_CONNS" of nested struct "DB" with prefix "MY_APP" is read from path "MY_APP/DB/MAX_CONNS".
+	Separator string
+	// RawQuery is appended to the URL, e.g. "raw" to get the raw value from Consul.
+	RawQuery string
+	// LowerCase reports whether to lower case the path, e.g. "myapp/db/host".
+	LowerCase bool
+	// Base64 reports whether the response body is base64-encoded.
+	Base64 bool
+	// Header is added to the request, e.g. the token for authentication.
+	Header http.Header
+	// Client is used to send request, default is http.DefaultClient.
+	Client *http.Client
+	// Timeout limits the time of each request, default is 5 seconds.
+	Timeout time.Duration
+	// Retries is the number of retries after the request failed by network error or 5xx status.
+	Retries int
+	// RetryInterval is the wait time before retry, default is 100 milliseconds.
+	RetryInterval time.Duration
+	// TTL is the time that values are cached, the value is not cached if zero.
+	TTL time.Duration
+}
+
+// NewKVGetter return a Getter that get value from a key/value store over HTTP such as Consul,
+// the value of key is the response body of GET BaseURL+path, and the key is not set if 404.
+func NewKVGetter(cfg KVConfig) Getter {
+	if cfg.Separator == "" {
+		cfg.Separator = "/"
+	}
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = 100 * time.Millisecond
+	}
+	return &kvGetter{cfg: cfg, cache: make(map[string]kvEntry)}
+}
+
+// kvEntry is the cached value of key.
+type kvEntry struct {
+	value   string
+	found   bool
+	expires time.Time
+}
+
+type kvGetter struct {
+	cfg KVConfig
+
+	mu    sync.Mutex
+	cache map[string]kvEntry // path -> entry
+}
+
+// Merge joins the prefix and key with Separator, the merged key is the path in store.
+func (g *kvGetter) Merge(prefix string, key string) string {
+	if prefix != "" && key != "" {
+		return prefix + g.cfg.Separator + key
+	}
+	return prefix + key
+}
+
+// path return the path in store of the merged key.
+func (g *kvGetter) path(key string) string {
+	if g.cfg.LowerCase {
+		return strings.ToLower(key)
+	}
+	return key
+}
+
+func (g *kvGetter) Get(key string) (string, bool, error) {
+	path := g.path(key)
+	if g.cfg.TTL > 0 {
+		g.mu.Lock()
+		entry, ok := g.cache[path]
+		g.mu.Unlock()
+		if ok && time.Now().Before(entry.expires) {
+			return entry.value, entry.found, nil
+		}
+	}
+
+	var value string
+	var found, retry bool
+	var err error
+	for i := 0; ; i++ {
+		value, found, retry, err = g.fetch(path)
+		if err == nil || !retry || i >= g.cfg.Retries {
+			break
+		}
+		time.Sleep(g.cfg.RetryInterval)
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("env: getting '%s' from '%s': %v", key, path, err)
+	}
+
+	if g.cfg.TTL > 0 {
+		g.mu.Lock()
+		g.cache[path] = kvEntry{value: value, found: found, expires: time.Now().Add(g.cfg.TTL)}
+		g.mu.Unlock()
+	}
+	return value, found, nil
+}
+
+// fetch sends the request once, it reports whether the error is temporary and worth retrying.
+func (g *kvGetter) fetch(path string) (value string, found bool, retry bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), g.cfg.Timeout)
+	defer cancel()
+
+	url := g.cfg.BaseURL + path
+	if g.cfg.RawQuery != "" {
+		url += "?" + g.cfg.RawQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", false, false, err
+	}
+	for k, v := range g.cfg.Header {
+		req.Header[k] = v
+	}
+	resp, err := g.cfg.Client.Do(req)
+	if err != nil {
+		return "", false, true, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", false, false, nil
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return "", false, true, fmt.Errorf("unexpected status '%s'", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return "", false, false, fmt.Errorf("unexpected status '%s'", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", false, true, err
+	}
+	if g.cfg.Base64 {
+		if data, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(data))); err != nil {
+			return "", false, false, errors.New("invalid base64 encoding")
+		}
+	}
+	return string(data), true, false, nil
+}
@@ -0,0 +1,201 @@
+package env_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+)
+
+type KVConfig struct {
+	Host    string        `env:"HOST"`
+	Port    int           `env:"PORT,default=8080"`
+	Timeout time.Duration `env:"TIMEOUT"`
+}
+
+type KVDatabase struct {
+	MaxConns int `env:"MAX_CONNS"`
+}
+
+// newKVServer return a server that serves the values by path.
+func newKVServer(t *testing.T, values map[string]string) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.RawQuery != "" && r.URL.RawQuery != "raw" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		value, ok := values[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(value))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestKVGetter(t *testing.T) {
+	server, _ := newKVServer(t, map[string]string{
+		"/v1/kv/MYAPP/HOST":    "db.local",
+		"/v1/kv/MYAPP/TIMEOUT": "3s",
+		"/v1/kv/myapp/host":    "lower.local",
+		"/v1/kv/MYAPP.HOST":    "dot.local",
+	})
+
+	g := env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/v1/kv/"})
+	cfg := &KVConfig{}
+	require.Nil(t, env.New(env.WithGetter(g), env.WithPrefix("MYAPP")).Load(cfg))
+	require.Equal(t, &KVConfig{Host: "db.local", Port: 8080, Timeout: 3 * time.Second}, cfg)
+
+	g = env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/v1/kv/", LowerCase: true, RawQuery: "raw"})
+	v, err := env.Get[string]("HOST", env.WithGetter(g), env.WithPrefix("MYAPP"))
+	require.Nil(t, err)
+	require.Equal(t, "lower.local", v)
+
+	g = env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/v1/kv/", Separator: "."})
+	v, err = env.Get[string]("HOST", env.WithGetter(g), env.WithPrefix("MYAPP"))
+	require.Nil(t, err)
+	require.Equal(t, "dot.local", v)
+
+	_, err = env.Get[string]("PORT", env.WithGetter(g), env.WithPrefix("MYAPP"))
+	require.True(t, errors.Is(err, env.ErrNotFound))
+}
+
+func TestKVGetter_Merge(t *testing.T) {
+	// The '_' in prefix and key is kept, only the segments are joined with Separator.
+	server, _ := newKVServer(t, map[string]string{
+		"/MY_APP/DB/MAX_CONNS": "10",
+		"/my_app.db.max_conns": "20",
+	})
+	type Config struct {
+		DB KVDatabase `env:"DB"`
+	}
+
+	g := env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/"})
+	cfg := &Config{}
+	require.Nil(t, env.New(env.WithGetter(g), env.WithPrefix("MY_APP")).Load(cfg))
+	require.Equal(t, 10, cfg.DB.MaxConns)
+
+	g = env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/", Separator: ".", LowerCase: true})
+	cfg = &Config{}
+	require.Nil(t, env.New(env.WithGetter(g), env.WithPrefix("MY_APP")).Load(cfg))
+	require.Equal(t, 20, cfg.DB.MaxConns)
+}
+
+func TestKVGetter_Base64(t *testing.T) {
+	server, _ := newKVServer(t, map[string]string{
+		"/HOST": base64.StdEncoding.EncodeToString([]byte("db.local")) + "\n",
+		"/PORT": "not base64!",
+	})
+
+	g := env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/", Base64: true})
+	v, err := env.Get[string]("HOST", env.WithGetter(g))
+	require.Nil(t, err)
+	require.Equal(t, "db.local", v)
+
+	_, err = env.Get[int]("PORT", env.WithGetter(g))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "invalid base64 encoding")
+}
+
+func TestKVGetter_Header(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Consul-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("db.local"))
+	}))
+	defer server.Close()
+
+	g := env.NewKVGetter(env.KVConfig{
+		BaseURL: server.URL + "/",
+		Header:  http.Header{"X-Consul-Token": []string{"token"}},
+	})
+	v, err := env.Get[string]("HOST", env.WithGetter(g))
+	require.Nil(t, err)
+	require.Equal(t, "db.local", v)
+
+	g = env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/", Retries: 3})
+	_, err = env.Get[string]("HOST", env.WithGetter(g))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "403")
+}
+
+func TestKVGetter_Retries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("db.local"))
+	}))
+	defer server.Close()
+
+	g := env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/", RetryInterval: time.Millisecond})
+	_, err := env.Get[string]("HOST", env.WithGetter(g))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "503")
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	atomic.StoreInt32(&requests, 0)
+	g = env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/", Retries: 2, RetryInterval: time.Millisecond})
+	v, err := env.Get[string]("HOST", env.WithGetter(g))
+	require.Nil(t, err)
+	require.Equal(t, "db.local", v)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestKVGetter_Timeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	g := env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/", Timeout: 20 * time.Millisecond})
+	_, err := env.Get[string]("HOST", env.WithGetter(g))
+	require.NotNil(t, err)
+	require.True(t, strings.Contains(err.Error(), "context deadline exceeded"), err.Error())
+}
+
+func TestKVGetter_TTL(t *testing.T) {
+	server, requests := newKVServer(t, map[string]string{"/HOST": "db.local"})
+
+	g := env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/", TTL: time.Hour})
+	for i := 0; i < 3; i++ {
+		require.Nil(t, env.New(env.WithGetter(g)).Load(&KVConfig{}))
+	}
+	// HOST, PORT and TIMEOUT are requested once, include the key not found.
+	require.Equal(t, int32(3), atomic.LoadInt32(requests))
+
+	atomic.StoreInt32(requests, 0)
+	g = env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/", TTL: 10 * time.Millisecond})
+	require.Nil(t, env.New(env.WithGetter(g)).Load(&KVConfig{}))
+	time.Sleep(20 * time.Millisecond)
+	require.Nil(t, env.New(env.WithGetter(g)).Load(&KVConfig{}))
+	require.Equal(t, int32(6), atomic.LoadInt32(requests))
+
+	// The value is not cached by default.
+	atomic.StoreInt32(requests, 0)
+	g = env.NewKVGetter(env.KVConfig{BaseURL: server.URL + "/"})
+	require.Nil(t, env.New(env.WithGetter(g)).Load(&KVConfig{}))
+	require.Nil(t, env.New(env.WithGetter(g)).Load(&KVConfig{}))
+	require.Equal(t, int32(6), atomic.LoadInt32(requests))
+}
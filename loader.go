@@ -12,12 +12,55 @@ import (
 
 const (
 	defaultTagName = "env"
+
+	// profileDefaultKeyword is the prefix of tag option 'default@<profile>'
+	profileDefaultKeyword = "default@"
 )
 
 // tagInfo maintains information about the struct tags
 type tagInfo struct {
-	key    string
-	defVal string
+	key      string
+	defVal   string
+	unit     string
+	layout   string
+	loc      *time.Location
+	format   string
+	encoding string
+	secret   bool
+	unset    bool
+	inline   bool
+	absolute bool    // ignore the inherited prefix
+	prefix   *string // replace the key when merged as prefix of nested struct
+	empty    bool    // apply the empty value if the key is set
+	override *bool   // override the non-zero value, nil means WithOverride
+	merge    string  // merge mode of slice and map
+	sep      string  // separator of slice, array and map elements
+
+	profiles map[string]string // profile -> default value, see WithProfile
+}
+
+// separator return the separator of slice, array and map elements, default is space.
+func (t *tagInfo) separator() string {
+	if t.sep != "" {
+		return t.sep
+	}
+	return " "
+}
+
+// defaultValue return the default value of profile, or the plain default if the profile has no default.
+func (t *tagInfo) defaultValue(profile string) string {
+	if v, ok := t.profiles[profile]; ok {
+		return v
+	}
+	return t.defVal
+}
+
+// segment return the key that merged as prefix of nested struct.
+func (t *tagInfo) segment() string {
+	if t.prefix != nil {
+		return *t.prefix
+	}
+	return t.key
 }
 
 // Loader populates the specified struct based on environment variables
@@ -62,20 +105,50 @@ func (p *Loader) loadInterface(i interface{}, prefix string) error {
 		return ErrNotStructPtr
 	}
 
-	return p.loadValue(refVal, prefix)
+	if err := p.analyze(refVal, prefix); err != nil {
+		return err
+	}
+	profile, err := p.profile()
+	if err != nil {
+		return err
+	}
+	return p.loadValue(refVal, prefix, profile)
+}
+
+// profile return the active profile set by WithProfile, or read from the key set by WithProfileKey.
+func (p *Loader) profile() (string, error) {
+	if p.opts.profile != "" || p.opts.profileKey == "" {
+		return p.opts.profile, nil
+	}
+	value, _, err := p.lookup(p.opts.profileKey)
+	if err != nil {
+		return "", err
+	}
+	return value, nil
 }
 
-func (p *Loader) loadValue(refVal reflect.Value, prefix string) error {
+// lookup return the value of key from the flags bound by BindFlags if set, or from the Getter.
+func (p *Loader) lookup(key string) (string, bool, error) {
+	if p.opts.flags != nil {
+		if value, set := p.opts.flags.lookup(key); set {
+			return value, true, nil
+		}
+	}
+	return p.opts.getter.Get(key)
+}
+
+func (p *Loader) loadValue(refVal reflect.Value, prefix string, profile string) error {
 	refType := refVal.Type()
 
 	for i := 0; i < refType.NumField(); i++ {
 		field := refVal.Field(i)
-		if !field.CanSet() {
+		structField := refType.Field(i)
+		// the fields of unexported embedded struct can be set
+		if !field.CanSet() && !(structField.Anonymous && field.Kind() == reflect.Struct) {
 			continue
 		}
 
-		structField := refType.Field(i)
-		tag, err := p.parseTags(structField)
+		tag, err := p.parseTags(refType.Name()+"."+structField.Name, structField)
 		if err != nil {
 			return err
 		}
@@ -83,96 +156,443 @@ func (p *Loader) loadValue(refVal reflect.Value, prefix string) error {
 			continue
 		}
 
-		// create a new object if nil pointer for struct-type
-		if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
-			if field.IsNil() {
-				field.Set(reflect.New(field.Type().Elem()))
+		// the fields of inline struct are loaded into the key space of parent
+		if tag.inline {
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					field.Set(reflect.New(field.Type().Elem()))
+				}
+				field = field.Elem()
 			}
-			field = field.Elem()
+			if err := p.loadValue(field, prefix, profile); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.CanSet() {
+			continue
 		}
 
-		if field.Kind() == reflect.Struct && field.CanAddr() {
-			if len(getSetters(field)) == 0 {
-				if err := p.loadValue(field.Addr().Elem(), p.opts.getter.Merge(prefix, tag.key)); err != nil {
+		// the field in json or yaml format is decoded from a single value as a whole
+		if tag.format == "" {
+			// create a new object if nil pointer for struct-type
+			if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct && p.lookupParser(field.Type()) == nil {
+				if field.IsNil() {
+					field.Set(reflect.New(field.Type().Elem()))
+				}
+				field = field.Elem()
+			}
+
+			if p.isNestedStruct(field) {
+				if err := p.loadValue(field.Addr().Elem(), p.mergeKey(prefix, tag.segment(), tag), profile); err != nil {
 					return err
 				}
 				continue
 			}
 		}
 
-		if !field.IsZero() && !p.opts.override {
+		key := p.mergeKey(prefix, tag.key, tag)
+
+		if !field.IsZero() && !p.isOverride(tag) {
 			continue
 		}
 
 		// Get value by specified key
-		key := p.opts.getter.Merge(prefix, tag.key)
-		value, found, err := p.opts.getter.Get(key)
+		value, found, err := p.lookup(key)
 		if err != nil {
 			return err
 		}
 		// Use default value if the key not be set and field value is zero.
 		if !found && field.IsZero() {
-			value = tag.defVal
+			value = tag.defaultValue(profile)
 		}
-		if value == "" {
-			continue
+		resolved := value
+		if found {
+			if resolved, err = p.resolve(key, value); err != nil {
+				return err
+			}
 		}
-
-		if err := setField(field, value); err != nil {
-			return &ParseError{
+		plaintext, err := p.decrypt(key, resolved)
+		if err != nil {
+			return err
+		}
+		if plaintext == "" {
+			// the empty value is applied only if the key is set and empty is allowed
+			if !found || !(tag.empty || p.opts.empty) {
+				continue
+			}
+			setEmpty(field)
+		} else if err := p.mergeField(field, plaintext, tag); err != nil {
+			pe := &ParseError{
 				KeyName:   key,
 				FieldName: refType.Name() + "." + structField.Name,
 				TypeName:  field.Type().String(),
 				Value:     value,
 				Err:       err,
 			}
+			// the value replaced by resolving or decryption is secret as the field with tag option 'secret'
+			if tag.secret || plaintext != value {
+				pe.Value, pe.Err = redacted, RedactError(err)
+			}
+			return pe
+		} else if opt, ok := asOptional(field); ok && !found {
+			opt.setSource(SourceDefault)
+		}
+
+		if found && (tag.unset || p.opts.unset) {
+			if u, ok := p.opts.getter.(Unsetter); ok {
+				if err := u.Unset(key); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
 }
 
 // parseTags split the struct tag's into the expected key and desired option, if any.
-// return nil if no tags set.
-func (p *Loader) parseTags(structField reflect.StructField) (*tagInfo, error) {
+// return nil if no tags set. The name is the struct name and field name used by errors, e.g. "Config.Port".
+func (p *Loader) parseTags(name string, structField reflect.StructField) (*tagInfo, error) {
 	structTag := structField.Tag
 	value, ok := structTag.Lookup(p.opts.tagName)
 	if !ok {
+		// the anonymous struct without tag is inlined
+		if structField.Anonymous && p.isNestedType(structField.Type) {
+			return &tagInfo{inline: true}, nil
+		}
 		return nil, nil
 	}
 
 	values := strings.SplitN(value, ",", -1)
 	key, args := values[0], values[1:]
-	if key == "" || key == "-" {
+	if key == "-" || (key == "" && !hasInlineOption(args)) {
 		return nil, nil
 	}
 
+	// the absolute key ignores the inherited prefix, e.g. "/HOME"
+	absolute := strings.HasPrefix(key, "/")
+	if absolute {
+		key = key[1:]
+		if key == "" {
+			return nil, fmt.Errorf("env: assigning '%s': invalid key in tag '%s', absolute key cannot be empty", name, structField.Tag)
+		}
+	}
+
 	if strings.Contains(key, " ") {
-		return nil, fmt.Errorf("env: assigning '%s': invalid key in tag '%s', cannot contain white space characters", structField.Name, structField.Tag)
+		return nil, fmt.Errorf("env: assigning '%s': invalid key in tag '%s', cannot contain white space characters", name, structField.Tag)
 	}
 
 	tags := &tagInfo{
-		key:    key,
-		defVal: "",
+		key:      key,
+		defVal:   "",
+		absolute: absolute,
 	}
 
 	for _, arg := range args {
 		x := strings.SplitN(arg, "=", 2)
 		k := x[0]
+		if strings.HasPrefix(k, profileDefaultKeyword) {
+			profile := k[len(profileDefaultKeyword):]
+			if len(x) != 2 || profile == "" {
+				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword '%s' from tag '%s', format sample: 'default@dev=xxx'", name, k, structField.Tag)
+			}
+			if _, ok := tags.profiles[profile]; ok {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword '%s' in tag '%s', it can be set only once", name, k, structField.Tag)
+			}
+			if tags.profiles == nil {
+				tags.profiles = make(map[string]string)
+			}
+			tags.profiles[profile] = x[1]
+			continue
+		}
 		switch k {
 		case "default":
 			if len(x) != 2 {
-				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword 'default' from tag '%s', format sample: 'default=xxx'", structField.Name, structField.Tag)
+				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword 'default' from tag '%s', format sample: 'default=xxx'", name, structField.Tag)
 			}
 			tags.defVal = x[1]
+		case "unit":
+			if len(x) != 2 || x[1] != "bytes" {
+				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword 'unit' from tag '%s', format sample: 'unit=bytes'", name, structField.Tag)
+			}
+			tags.unit = x[1]
+		case "layout":
+			if len(x) != 2 || x[1] == "" {
+				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword 'layout' from tag '%s', format sample: 'layout=RFC1123'", name, structField.Tag)
+			}
+			tags.layout = lookupTimeLayout(x[1])
+		case "loc":
+			if len(x) != 2 || x[1] == "" {
+				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword 'loc' from tag '%s', format sample: 'loc=Asia/Shanghai'", name, structField.Tag)
+			}
+			loc, err := time.LoadLocation(x[1])
+			if err != nil {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword 'loc' in tag '%s': %v", name, structField.Tag, err)
+			}
+			tags.loc = loc
+		case formatJSON, formatYAML:
+			if len(x) != 1 || tags.format != "" {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword '%s' in tag '%s', only one of 'json' and 'yaml' can be set without value", name, k, structField.Tag)
+			}
+			tags.format = k
+		case "encoding":
+			if len(x) != 2 || !isEncoding(x[1]) {
+				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword 'encoding' from tag '%s', format sample: 'encoding=base64', supported encodings: raw, base64, base64url, hex", name, structField.Tag)
+			}
+			tags.encoding = x[1]
+		case "secret":
+			if len(x) != 1 {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword 'secret' in tag '%s', it cannot have a value", name, structField.Tag)
+			}
+			tags.secret = true
+		case "unset":
+			if len(x) != 1 {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword 'unset' in tag '%s', it cannot have a value", name, structField.Tag)
+			}
+			tags.unset = true
+		case "inline", "squash":
+			if len(x) != 1 || key != "" {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword '%s' in tag '%s', the key must be empty, format sample: ',%s'", name, k, structField.Tag, k)
+			}
+			if !p.isNestedType(structField.Type) {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword '%s' in tag '%s', the field must be a struct", name, k, structField.Tag)
+			}
+			tags.inline = true
+		case "allowempty":
+			if len(x) != 1 {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword 'allowempty' in tag '%s', it cannot have a value", name, structField.Tag)
+			}
+			tags.empty = true
+		case "override", "nooverride":
+			if len(x) != 1 || tags.override != nil {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword '%s' in tag '%s', only one of 'override' and 'nooverride' can be set without value", name, k, structField.Tag)
+			}
+			override := k == "override"
+			tags.override = &override
+		case "merge":
+			if len(x) != 2 || !isMergeMode(x[1]) {
+				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword 'merge' from tag '%s', format sample: 'merge=append', supported modes: append, replace, merge", name, structField.Tag)
+			}
+			if !isMergeable(structField.Type) {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword 'merge' in tag '%s', the field must be a slice or map", name, structField.Tag)
+			}
+			tags.merge = x[1]
+		case "noprefix":
+			if len(x) != 1 {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword 'noprefix' in tag '%s', it cannot have a value", name, structField.Tag)
+			}
+			tags.absolute = true
+		case "sep":
+			if len(x) != 2 || x[1] == "" {
+				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword 'sep' from tag '%s', format sample: 'sep=;'", name, structField.Tag)
+			}
+			if !isCollection(structField.Type) {
+				return nil, fmt.Errorf("env: assigning '%s': invalid keyword 'sep' in tag '%s', the field must be a slice, array or map", name, structField.Tag)
+			}
+			tags.sep = x[1]
+		case "prefix":
+			if len(x) != 2 || strings.Contains(x[1], " ") {
+				return nil, fmt.Errorf("env: assigning '%s': cannot parse keyword 'prefix' from tag '%s', format sample: 'prefix=OTHER'", name, structField.Tag)
+			}
+			tags.prefix = &x[1]
 		default:
-			//
+			return nil, fmt.Errorf("env: assigning '%s': unknown keyword '%s' in tag '%s'", name, k, structField.Tag)
 		}
 	}
+	if err := checkTagType(name, structField, tags); err != nil {
+		return nil, err
+	}
+	if strings.Contains(tags.layout, " ") && tags.sep == "" && isCollection(structField.Type) {
+		return nil, fmt.Errorf("env: assigning '%s': invalid keyword 'layout' in tag '%s', the layout contains space, set the separator of elements by keyword 'sep'", name, structField.Tag)
+	}
+	if tags.prefix != nil && (tags.format != "" || tags.inline || !p.isNestedType(structField.Type)) {
+		return nil, fmt.Errorf("env: assigning '%s': invalid keyword 'prefix' in tag '%s', the field must be a nested struct", name, structField.Tag)
+	}
 	return tags, nil
 }
 
+// isOverride reports whether the non-zero field should be overridden, the tag options
+// 'override' and 'nooverride' take precedence over WithOverride, and the slice and map with
+// merge mode 'append' or 'merge' are always loaded.
+func (p *Loader) isOverride(tag *tagInfo) bool {
+	if tag.merge == mergeAppend || tag.merge == mergeMerge {
+		return true
+	}
+	if tag.override != nil {
+		return *tag.override
+	}
+	return p.opts.override
+}
+
+// mergeKey merge the prefix and key by Getter.Merge, the prefix is ignored if the tag is absolute.
+func (p *Loader) mergeKey(prefix string, key string, tag *tagInfo) string {
+	if tag.absolute {
+		prefix = ""
+	}
+	return p.opts.getter.Merge(prefix, key)
+}
+
+func hasInlineOption(args []string) bool {
+	for _, arg := range args {
+		if arg == "inline" || arg == "squash" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTagType checks the tag options that apply to specific types match the type of field.
+func checkTagType(name string, structField reflect.StructField, tags *tagInfo) error {
+	check := func(keyword string, want string, ok func(t reflect.Type) bool) error {
+		if matchValueType(structField.Type, ok) {
+			return nil
+		}
+		return fmt.Errorf("env: assigning '%s': invalid keyword '%s' in tag '%s', the field must be %s", name, keyword, structField.Tag, want)
+	}
+
+	if tags.unit != "" {
+		if err := check("unit", "an integer", isInteger); err != nil {
+			return err
+		}
+	}
+	if tags.encoding != "" {
+		if err := check("encoding", "[]byte or [N]byte", isBytes); err != nil {
+			return err
+		}
+	}
+	if tags.layout != "" {
+		if err := check("layout", "time.Time", isTime); err != nil {
+			return err
+		}
+	}
+	if tags.loc != nil {
+		if err := check("loc", "time.Time", isTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchValueType reports whether the value type of field, or of the elements of slice,
+// array and map, satisfies ok.
+func matchValueType(t reflect.Type, ok func(t reflect.Type) bool) bool {
+	t = valueType(t)
+	if ok(t) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return matchValueType(t.Elem(), ok)
+	case reflect.Map:
+		return matchValueType(t.Key(), ok) || matchValueType(t.Elem(), ok)
+	}
+	return false
+}
+
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isBytes(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+func isTime(t reflect.Type) bool {
+	return t == timeType
+}
+
+// valueType return the type of value that set to the field, the pointer and Optional are unwrapped.
+func valueType(t reflect.Type) reflect.Type {
+	for {
+		if elem, ok := optionalElem(t); ok {
+			t = elem
+		} else if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		} else {
+			return t
+		}
+	}
+}
+
+// isCollection reports whether the value of field is split into elements, i.e. slice, array or map.
+func isCollection(t reflect.Type) bool {
+	switch valueType(t).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// isNestedStruct reports whether the field is a struct whose fields should be loaded recursively
+// rather than deserialized from a single value.
+func (p *Loader) isNestedStruct(field reflect.Value) bool {
+	if field.Kind() != reflect.Struct || !field.CanAddr() {
+		return false
+	}
+	if p.lookupParser(field.Type()) != nil {
+		return false
+	}
+	if _, ok := optionalElem(field.Type()); ok {
+		return false
+	}
+	return len(getSetters(field)) == 0
+}
+
+// isNestedType reports whether the struct or pointer to struct type should be loaded recursively.
+func (p *Loader) isNestedType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr && p.lookupParser(t) == nil {
+		t = t.Elem()
+	}
+	return p.isNestedStruct(reflect.New(t).Elem())
+}
+
+// setEmpty set the empty value to the field: the slice and map are set to empty rather than nil,
+// the pointer is set to the empty value of its element, the Optional is set with the empty
+// value of T, and the others are set to zero.
+func setEmpty(field reflect.Value) {
+	if opt, ok := asOptional(field); ok {
+		setEmpty(opt.elem())
+		opt.setSource(SourceGetter)
+		return
+	}
+	switch field.Kind() {
+	case reflect.Slice:
+		field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+	case reflect.Map:
+		field.Set(reflect.MakeMap(field.Type()))
+	case reflect.Ptr:
+		elem := reflect.New(field.Type().Elem())
+		setEmpty(elem.Elem())
+		field.Set(elem)
+	default:
+		field.Set(reflect.Zero(field.Type()))
+	}
+}
+
 // setField set value to the struct field
-func setField(field reflect.Value, value string) error {
+func (p *Loader) setField(field reflect.Value, value string, tag *tagInfo) error {
+	// the value of Optional is set as T
+	if opt, ok := asOptional(field); ok {
+		if err := p.setField(opt.elem(), value, tag); err != nil {
+			return err
+		}
+		opt.setSource(SourceGetter)
+		return nil
+	}
+
+	if tag.format != "" {
+		return decodeField(field, value, tag.format)
+	}
+
+	if ok, err := p.parseByType(field, value); ok {
+		return err
+	}
+
 	refType := field.Type()
 	// create a new object if nil pointer
 	if refType.Kind() == reflect.Ptr {
@@ -183,6 +603,19 @@ func setField(field reflect.Value, value string) error {
 		field = field.Elem()
 	}
 
+	if ok, err := p.parseByType(field, value); ok {
+		return err
+	}
+
+	if refType == timeType && (tag.layout != "" || tag.loc != nil) {
+		t, err := parseTime(value, tag)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
 	if setters := getSetters(field); len(setters) != 0 {
 		var errs []error
 		for _, setter := range setters {
@@ -201,7 +634,11 @@ func setField(field reflect.Value, value string) error {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		var err error
 		var v int64
-		if _, ok := field.Interface().(time.Duration); ok {
+		if tag.unit == "bytes" {
+			var u uint64
+			u, err = parseBytes(value, refType.Bits(), true)
+			v = int64(u)
+		} else if _, ok := field.Interface().(time.Duration); ok {
 			var d time.Duration
 			d, err = time.ParseDuration(value)
 			v = int64(d)
@@ -215,7 +652,13 @@ func setField(field reflect.Value, value string) error {
 
 		field.SetInt(v)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		v, err := strconv.ParseUint(value, 0, refType.Bits())
+		var err error
+		var v uint64
+		if tag.unit == "bytes" {
+			v, err = parseBytes(value, refType.Bits(), false)
+		} else {
+			v, err = strconv.ParseUint(value, 0, refType.Bits())
+		}
 		if err != nil {
 			return err
 		}
@@ -233,27 +676,48 @@ func setField(field reflect.Value, value string) error {
 		}
 		field.SetBool(v)
 	case reflect.Slice:
-		parts := strings.Split(value, " ")
+		if refType.Elem().Kind() == reflect.Uint8 {
+			b, err := decodeBytes(value, tag.encoding)
+			if err != nil {
+				return err
+			}
+			field.SetBytes(b)
+			return nil
+		}
+		parts := strings.Split(value, tag.separator())
 		sl := reflect.MakeSlice(refType, len(parts), len(parts))
-		for i, p := range parts {
-			if err := setField(sl.Index(i), p); err != nil {
+		for i, part := range parts {
+			if err := p.setField(sl.Index(i), part, tag); err != nil {
 				return err
 			}
 		}
 		field.Set(sl)
 	case reflect.Array:
-		parts := strings.Split(value, " ")
+		if refType.Elem().Kind() == reflect.Uint8 && tag.encoding != "" {
+			b, err := decodeBytes(value, tag.encoding)
+			if err != nil {
+				return err
+			}
+			if len(b) != field.Len() {
+				return fmt.Errorf("expected %d bytes for %s but got %d", field.Len(), refType.String(), len(b))
+			}
+			for i := range b {
+				field.Index(i).SetUint(uint64(b[i]))
+			}
+			return nil
+		}
+		parts := strings.Split(value, tag.separator())
 		if len(parts) != field.Len() {
 			return fmt.Errorf("not enough elements for set %s", refType.String())
 		}
-		for i, p := range parts {
-			if err := setField(field.Index(i), p); err != nil {
+		for i, part := range parts {
+			if err := p.setField(field.Index(i), part, tag); err != nil {
 				return err
 			}
 		}
 	case reflect.Map:
 		mp := reflect.MakeMap(refType)
-		pairs := strings.Split(value, " ")
+		pairs := strings.Split(value, tag.separator())
 
 		for _, pair := range pairs {
 			kv := strings.Split(pair, ":")
@@ -261,11 +725,11 @@ func setField(field reflect.Value, value string) error {
 				return errors.New("invalid map items")
 			}
 			k := reflect.New(refType.Key()).Elem()
-			if err := setField(k, kv[0]); err != nil {
+			if err := p.setField(k, kv[0], tag); err != nil {
 				return err
 			}
 			v := reflect.New(refType.Elem()).Elem()
-			if err := setField(v, strings.Join(kv[1:], ":")); err != nil {
+			if err := p.setField(v, strings.Join(kv[1:], ":"), tag); err != nil {
 				return err
 			}
 			mp.SetMapIndex(k, v)
@@ -2,8 +2,10 @@ package env_test
 
 import (
 	"encoding/json"
+	"errors"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
 )
 
 var prefix = "env"
@@ -220,16 +223,21 @@ func init() {
 	SpecEnvs = strings.TrimSuffix(SpecEnvs, "\n")
 }
 
-func TestEnv_Load_ByEnv(t *testing.T) {
-	os.Clearenv()
+// specEnvs return the environment variables in SpecEnvs with prefix.
+func specEnvs() map[string]string {
+	envs := make(map[string]string)
 	for _, line := range strings.Split(SpecEnvs, "\n") {
 		if line == "" {
 			continue
 		}
 		kv := strings.Split(line, "=")
-		err := os.Setenv(strings.ToUpper(prefix+"_"+kv[0]), kv[1])
-		require.Nil(t, err)
+		envs[strings.ToUpper(prefix+"_"+kv[0])] = kv[1]
 	}
+	return envs
+}
+
+func TestEnv_Load_ByEnv(t *testing.T) {
+	envtest.Set(t, specEnvs())
 
 	s := &Specification{}
 
@@ -394,10 +402,9 @@ func TestEnv_Load_ByEnv(t *testing.T) {
 }
 
 func TestEnv_Load_Default(t *testing.T) {
-	os.Clearenv()
 	s := &Specification{}
 
-	l := env.New(env.WithPrefix(prefix))
+	l := envtest.NewLoader(nil, env.WithPrefix(prefix))
 	err := l.Load(s)
 	require.Nil(t, err, "%+v", err)
 
@@ -409,7 +416,6 @@ func TestEnv_Load_Default(t *testing.T) {
 }
 
 func TestEnv_Load_NotPtr(t *testing.T) {
-	os.Clearenv()
 	s := Specification{}
 	l := env.New(env.WithPrefix(prefix))
 	err := l.Load(s)
@@ -418,7 +424,6 @@ func TestEnv_Load_NotPtr(t *testing.T) {
 }
 
 func TestEnv_Load_NotStruct(t *testing.T) {
-	os.Clearenv()
 	var x string
 	l := env.New(env.WithPrefix(prefix))
 	err := l.Load(&x)
@@ -427,13 +432,12 @@ func TestEnv_Load_NotStruct(t *testing.T) {
 }
 
 func TestEnv_Load_Override(t *testing.T) {
-	os.Clearenv()
 	type Config struct {
 		Timeout int `env:"TIMEOUT,default=10"`
 	}
 
 	cfg := &Config{}
-	l := env.New(env.WithOverride(true))
+	l := envtest.NewLoader(nil, env.WithOverride(true))
 	err := l.Load(cfg)
 	require.Nil(t, err, "%+v", err)
 	require.Equal(t, 10, cfg.Timeout)
@@ -445,24 +449,429 @@ func TestEnv_Load_Override(t *testing.T) {
 	require.Equal(t, 5, cfg.Timeout)
 
 	// If key's value is non-zero, override it.
-	os.Clearenv()
-	os.Setenv("TIMEOUT", "100")
+	l = envtest.NewLoader(map[string]string{"TIMEOUT": "100"}, env.WithOverride(true))
 	cfg = &Config{Timeout: 5}
 	err = l.Load(cfg)
 	require.Nil(t, err, "%+v", err)
 	require.Equal(t, 100, cfg.Timeout)
 }
 
-func BenchmarkEnv_Load_ByEnv(b *testing.B) {
-	os.Clearenv()
-	for _, line := range strings.Split(SpecEnvs, "\n") {
-		if line == "" {
-			continue
-		}
-		kv := strings.Split(line, "=")
-		err := os.Setenv(strings.ToUpper(prefix+"_"+kv[0]), kv[1])
-		require.Nil(b, err)
+func TestEnv_Load_OverrideTag(t *testing.T) {
+	type Config struct {
+		Host    string `env:"HOST,override"`
+		Port    int    `env:"PORT,nooverride"`
+		Timeout int    `env:"TIMEOUT"`
+	}
+	envs := map[string]string{"HOST": "env", "PORT": "80", "TIMEOUT": "10"}
+
+	cfg := &Config{Host: "code", Port: 8080, Timeout: 5}
+	err := envtest.NewLoader(envs).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, &Config{Host: "env", Port: 8080, Timeout: 5}, cfg)
+
+	// The tag options take precedence over WithOverride.
+	cfg = &Config{Host: "code", Port: 8080, Timeout: 5}
+	err = envtest.NewLoader(envs, env.WithOverride(true)).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, &Config{Host: "env", Port: 8080, Timeout: 10}, cfg)
+
+	type Invalid struct {
+		Host string `env:"HOST,override,nooverride"`
+	}
+	err = env.New().Load(&Invalid{})
+	require.NotNil(t, err)
+}
+
+func TestEnv_Load_Unset(t *testing.T) {
+	type Config struct {
+		Password string `env:"PASSWORD,unset"`
+		Token    string `env:"TOKEN,unset"`
+		Port     int    `env:"PORT,unset"`
+		Host     string `env:"HOST"`
+	}
+
+	// The unset is tested with the process environment.
+	envtest.Set(t, map[string]string{"PASSWORD": "secret", "PORT": "x", "HOST": "localhost"})
+	envtest.Unset(t, "TOKEN")
+	cfg := &Config{}
+	err := env.New().Load(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, "secret", cfg.Password)
+
+	// The key is removed only after the value is set successfully.
+	_, found := os.LookupEnv("PASSWORD")
+	require.False(t, found)
+	_, found = os.LookupEnv("PORT")
+	require.True(t, found)
+	_, found = os.LookupEnv("HOST")
+	require.True(t, found)
+
+	// Unset all keys with WithUnsetAfterRead.
+	g := env.MapGetter(map[string]string{"PASSWORD": "secret", "HOST": "localhost"})
+	cfg = &Config{}
+	err = env.New(env.WithGetter(g), env.WithUnsetAfterRead(true)).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, "secret", cfg.Password)
+	require.Equal(t, "localhost", cfg.Host)
+
+	_, found, _ = g.Get("PASSWORD")
+	require.False(t, found)
+	_, found, _ = g.Get("HOST")
+	require.False(t, found)
+
+	type Invalid struct {
+		Password string `env:"PASSWORD,unset=true"`
+	}
+	err = env.New().Load(&Invalid{})
+	require.NotNil(t, err)
+}
+
+type InlineBase struct {
+	Name string `env:"NAME"`
+}
+
+type inlineMeta struct {
+	Version string `env:"VERSION,default=v1"`
+}
+
+type InlineLimits struct {
+	MaxConns int `env:"MAX_CONNS"`
+}
+
+type InlineTimeouts struct {
+	Timeout time.Duration `env:"TIMEOUT,default=1s"`
+}
+
+func TestEnv_Load_Inline(t *testing.T) {
+	type Config struct {
+		InlineBase
+		inlineMeta
+		Limits   InlineLimits    `env:",inline"`
+		Squashed *InlineTimeouts `env:",squash"`
+		Prefixed InlineBase      `env:"DB"`
+	}
+
+	envs := map[string]string{"APP_NAME": "app", "APP_MAX_CONNS": "10", "APP_DB_NAME": "db"}
+	cfg := &Config{}
+	err := envtest.NewLoader(envs, env.WithPrefix("APP")).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, "app", cfg.Name)
+	require.Equal(t, "v1", cfg.Version)
+	require.Equal(t, 10, cfg.Limits.MaxConns)
+	require.Equal(t, "db", cfg.Prefixed.Name)
+	require.Equal(t, time.Second, cfg.Squashed.Timeout)
+}
+
+func TestEnv_Load_InlineConflict(t *testing.T) {
+	type Config struct {
+		InlineBase
+		Name string `env:"NAME"`
+	}
+	err := env.New().Load(&Config{})
+	require.NotNil(t, err)
+	require.Equal(t, "env: assigning 'Config.Name': key 'NAME' is already used by 'InlineBase.Name'", err.Error())
+
+	type Duplicate struct {
+		Limits InlineLimits `env:",inline"`
+		Other  InlineLimits `env:",squash"`
+	}
+	_, err = env.New().Fields(&Duplicate{})
+	require.NotNil(t, err)
+	require.Equal(t, "env: assigning 'InlineLimits.MaxConns': key 'MAX_CONNS' is already used by 'InlineLimits.MaxConns'", err.Error())
+
+	type NotStruct struct {
+		Port int `env:",inline"`
+	}
+	err = env.New().Load(&NotStruct{})
+	require.NotNil(t, err)
+
+	type WithKey struct {
+		Limits InlineLimits `env:"LIMITS,inline"`
+	}
+	err = env.New().Load(&WithKey{})
+	require.NotNil(t, err)
+}
+
+func TestEnv_Load_Prefix(t *testing.T) {
+	type Postgres struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT,default=5432"`
+	}
+	type Config struct {
+		Home    string   `env:"/HOME"`
+		User    string   `env:"USER,noprefix"`
+		Name    string   `env:"NAME"`
+		DB      Postgres `env:"DB"`
+		Legacy  Postgres `env:"LEGACY,noprefix,prefix=PG"`
+		Replica Postgres `env:"REPLICA,prefix=RO"`
+		Root    Postgres `env:"ROOT,prefix="`
 	}
+
+	l := envtest.NewLoader(map[string]string{
+		"HOME":             "/root",
+		"USER":             "root",
+		"APP_NAME":         "app",
+		"APP_DB_HOST":      "db",
+		"PG_HOST":          "legacy",
+		"PG_PORT":          "5433",
+		"APP_RO_HOST":      "replica",
+		"APP_REPLICA_HOST": "wrong",
+	}, env.WithPrefix("APP"))
+	cfg := &Config{}
+	err := l.Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, "/root", cfg.Home)
+	require.Equal(t, "root", cfg.User)
+	require.Equal(t, "app", cfg.Name)
+	require.Equal(t, Postgres{Host: "db", Port: 5432}, cfg.DB)
+	require.Equal(t, Postgres{Host: "legacy", Port: 5433}, cfg.Legacy)
+	require.Equal(t, Postgres{Host: "replica", Port: 5432}, cfg.Replica)
+	require.Equal(t, Postgres{Host: "", Port: 5432}, cfg.Root)
+
+	type Conflict struct {
+		Port int      `env:"PORT"`
+		Root Postgres `env:"ROOT,prefix="`
+	}
+	err = l.Load(&Conflict{})
+	require.NotNil(t, err)
+	require.Equal(t, "env: assigning 'Postgres.Port': key 'APP_PORT' is already used by 'Conflict.Port'", err.Error())
+
+	type Invalid struct {
+		Port int `env:"PORT,prefix=X"`
+	}
+	err = l.Load(&Invalid{})
+	require.NotNil(t, err)
+
+	type Empty struct {
+		Port int `env:"/"`
+	}
+	err = l.Load(&Empty{})
+	require.NotNil(t, err)
+}
+
+func TestLoader_Fields_Prefix(t *testing.T) {
+	type Postgres struct {
+		Host string `env:"HOST"`
+	}
+	type Config struct {
+		Home   string   `env:"/HOME"`
+		Legacy Postgres `env:"LEGACY,noprefix,prefix=PG"`
+		DB     Postgres `env:"DB"`
+	}
+
+	fields, err := env.New(env.WithPrefix("APP")).Fields(&Config{})
+	require.Nil(t, err, "%+v", err)
+	require.Len(t, fields, 3)
+	require.Equal(t, "HOME", fields[0].Key)
+	require.Equal(t, []string{"HOME"}, fields[0].Path)
+	require.Equal(t, "PG_HOST", fields[1].Key)
+	require.Equal(t, []string{"PG", "HOST"}, fields[1].Path)
+	require.Equal(t, "APP_DB_HOST", fields[2].Key)
+	require.Equal(t, []string{"DB", "HOST"}, fields[2].Path)
+}
+
+func TestEnv_Load_Lint(t *testing.T) {
+	type Server struct {
+		Port int `env:"PORT"`
+	}
+	type Config struct {
+		Port     int            `env:"SERVER_PORT"`
+		Server   Server         `env:"SERVER"`
+		Timeout  int            `env:"TIMEOUT,defualt=10"`
+		Callback func()         `env:"CALLBACK"`
+		Chans    []chan int     `env:"CHANS"`
+		Values   map[string]any `env:"VALUES"`
+		JSON     map[string]any `env:"JSON,json"`
+		private  string         `env:"PRIVATE"`
+		ignored  string         `env:"-"`
+	}
+
+	cfg := &Config{}
+	err := envtest.NewLoader(map[string]string{"SERVER_PORT": "8080"}).Load(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, 0, cfg.Port, "no value should be read")
+
+	var lintErr *env.LintError
+	require.True(t, errors.As(err, &lintErr))
+	require.Equal(t, []string{"Server.Port", "Config.Timeout", "Config.Callback", "Config.Chans", "Config.Values", "Config.private"}, lintFields(lintErr))
+	require.Equal(t, "SERVER_PORT", lintErr.Issues[0].Key)
+	require.Equal(t, "env: assigning 'Server.Port': key 'SERVER_PORT' is already used by 'Config.Port'", lintErr.Issues[0].Err.Error())
+	require.Equal(t, "env: assigning 'Config.Timeout': unknown keyword 'defualt' in tag 'env:\"TIMEOUT,defualt=10\"'", lintErr.Issues[1].Err.Error())
+	require.Equal(t, "env: assigning 'Config.Callback': type 'func()' is not supported", lintErr.Issues[2].Err.Error())
+	require.Equal(t, "env: assigning 'Config.private': unexported field cannot be set, tag 'env:\"PRIVATE\"'", lintErr.Issues[5].Err.Error())
+	require.Len(t, strings.Split(err.Error(), "\n"), 6)
+	// All issues name the field in the same format.
+	for _, issue := range lintErr.Issues {
+		require.True(t, strings.HasPrefix(issue.Err.Error(), "env: assigning '"+issue.Field+"'"), issue.Err.Error())
+	}
+
+	_, err = env.New().Fields(cfg)
+	require.True(t, errors.As(err, &lintErr))
+	require.Len(t, lintErr.Issues, 6)
+
+	// The tag options must match the type of field.
+	type Mismatch struct {
+		Unit       string               `env:"UNIT,unit=bytes"`
+		Encoding   string               `env:"ENCODING,encoding=base64"`
+		Layout     int                  `env:"LAYOUT,layout=RFC1123"`
+		Loc        string               `env:"LOC,loc=UTC"`
+		Size       int64                `env:"SIZE,unit=bytes"`
+		Sizes      *[]uint              `env:"SIZES,unit=bytes"`
+		Bytes      env.Optional[[]byte] `env:"BYTES,encoding=hex"`
+		Array      [4]byte              `env:"ARRAY,encoding=hex"`
+		Times      map[string]time.Time `env:"TIMES,layout=unix,loc=UTC"`
+		TimePtr    *time.Time           `env:"TIME_PTR,layout=DateOnly"`
+		StringsLoc []string             `env:"STRINGS_LOC,loc=UTC"`
+	}
+	err = env.New().Load(&Mismatch{})
+	require.True(t, errors.As(err, &lintErr))
+	require.Equal(t, []string{"Mismatch.Unit", "Mismatch.Encoding", "Mismatch.Layout", "Mismatch.Loc", "Mismatch.StringsLoc"}, lintFields(lintErr))
+	require.Equal(t, "env: assigning 'Mismatch.Unit': invalid keyword 'unit' in tag 'env:\"UNIT,unit=bytes\"', the field must be an integer", lintErr.Issues[0].Err.Error())
+	require.Equal(t, "env: assigning 'Mismatch.Encoding': invalid keyword 'encoding' in tag 'env:\"ENCODING,encoding=base64\"', the field must be []byte or [N]byte", lintErr.Issues[1].Err.Error())
+	require.Equal(t, "env: assigning 'Mismatch.Layout': invalid keyword 'layout' in tag 'env:\"LAYOUT,layout=RFC1123\"', the field must be time.Time", lintErr.Issues[2].Err.Error())
+	require.Equal(t, "env: assigning 'Mismatch.Loc': invalid keyword 'loc' in tag 'env:\"LOC,loc=UTC\"', the field must be time.Time", lintErr.Issues[3].Err.Error())
+}
+
+func lintFields(err *env.LintError) []string {
+	var names []string
+	for _, issue := range err.Issues {
+		names = append(names, issue.Field)
+	}
+	return names
+}
+
+func TestEnv_Load_AllowEmpty(t *testing.T) {
+	type Config struct {
+		LogFile  string            `env:"LOG_FILE,default=app.log,allowempty"`
+		Output   *string           `env:"OUTPUT,allowempty"`
+		Hosts    []string          `env:"HOSTS,default=a b,allowempty"`
+		Labels   map[string]string `env:"LABELS,allowempty"`
+		Level    string            `env:"LEVEL,default=info,allowempty"`
+		Port     int               `env:"PORT,default=8080"`
+		Disabled string            `env:"DISABLED,default=x"`
+	}
+
+	envs := map[string]string{"LOG_FILE": "", "OUTPUT": "", "HOSTS": "", "LABELS": "", "PORT": "", "DISABLED": ""}
+	cfg := &Config{}
+	err := envtest.NewLoader(envs).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, "", cfg.LogFile)
+	require.NotNil(t, cfg.Output)
+	require.Equal(t, "", *cfg.Output)
+	require.Equal(t, []string{}, cfg.Hosts)
+	require.Equal(t, map[string]string{}, cfg.Labels)
+	// The unset key still falls back to default.
+	require.Equal(t, "info", cfg.Level)
+	// The empty value is ignored without allowempty.
+	require.Equal(t, 0, cfg.Port)
+	require.Equal(t, "", cfg.Disabled)
+
+	// The empty value is applied to all fields with WithAllowEmpty.
+	type Override struct {
+		Name  string `env:"NAME"`
+		Port  int    `env:"PORT"`
+		Debug bool   `env:"DEBUG"`
+	}
+	g := env.MapGetter(map[string]string{"NAME": "", "PORT": "", "DEBUG": ""})
+	o := &Override{Name: "x", Port: 1, Debug: true}
+	err = env.New(env.WithGetter(g), env.WithAllowEmpty(true), env.WithOverride(true)).Load(o)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, &Override{}, o)
+
+	o = &Override{Name: "x", Port: 1, Debug: true}
+	err = env.New(env.WithGetter(g), env.WithOverride(true)).Load(o)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, &Override{Name: "x", Port: 1, Debug: true}, o)
+}
+
+func TestEnv_Load_Profile(t *testing.T) {
+	type Nested struct {
+		Debug bool `env:"DEBUG,default@dev=true"`
+	}
+	type Config struct {
+		Level   string        `env:"LOG_LEVEL,default=info,default@dev=debug,default@prod=warn"`
+		Timeout time.Duration `env:"TIMEOUT,default@prod=10s"`
+		Port    int           `env:"PORT,default=8080"`
+		Nested  Nested        `env:"NESTED"`
+	}
+
+	load := func(g env.Getter, options ...env.Option) *Config {
+		cfg := &Config{}
+		err := env.New(append(options, env.WithGetter(g))...).Load(cfg)
+		require.Nil(t, err, "%+v", err)
+		return cfg
+	}
+	empty := env.MapGetter(nil)
+
+	// The plain default is used without profile or the profile has no default.
+	require.Equal(t, &Config{Level: "info", Port: 8080}, load(empty))
+	require.Equal(t, &Config{Level: "info", Port: 8080}, load(empty, env.WithProfile("staging")))
+
+	require.Equal(t, &Config{Level: "debug", Port: 8080, Nested: Nested{Debug: true}}, load(empty, env.WithProfile("dev")))
+	require.Equal(t, &Config{Level: "warn", Timeout: 10 * time.Second, Port: 8080}, load(empty, env.WithProfile("prod")))
+
+	// The value of key takes precedence over the profile default.
+	g := env.MapGetter(map[string]string{"LOG_LEVEL": "error"})
+	require.Equal(t, &Config{Level: "error", Port: 8080, Nested: Nested{Debug: true}}, load(g, env.WithProfile("dev")))
+
+	// The profile is read from the key, which is not merged with the prefix.
+	g = env.MapGetter(map[string]string{"APP_ENV": "prod"})
+	require.Equal(t, &Config{Level: "warn", Timeout: 10 * time.Second, Port: 8080},
+		load(g, env.WithProfileKey("APP_ENV"), env.WithPrefix("MYAPP")))
+	require.Equal(t, &Config{Level: "debug", Port: 8080, Nested: Nested{Debug: true}},
+		load(g, env.WithProfileKey("APP_ENV"), env.WithProfile("dev")))
+	require.Equal(t, &Config{Level: "info", Port: 8080}, load(empty, env.WithProfileKey("APP_ENV")))
+
+	fields, err := env.New(env.WithProfile("dev")).Fields(&Config{})
+	require.Nil(t, err)
+	require.Equal(t, "debug", fields[0].Default)
+	fields, err = env.New(env.WithProfileKey("APP_ENV"), env.WithGetter(g)).Fields(&Config{})
+	require.Nil(t, err)
+	require.Equal(t, "warn", fields[0].Default)
+	require.Equal(t, "10s", fields[1].Default)
+
+	type Invalid struct {
+		Level string `env:"LOG_LEVEL,default@=debug"`
+	}
+	err = env.New().Load(&Invalid{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "cannot parse keyword 'default@'")
+
+	type Duplicate struct {
+		Level string `env:"LOG_LEVEL,default@dev=debug,default@dev=info"`
+	}
+	err = env.New().Load(&Duplicate{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "it can be set only once")
+}
+
+func TestEnv_Load_SecretParseError(t *testing.T) {
+	type Config struct {
+		Pin     int           `env:"PIN,secret"`
+		Timeout time.Duration `env:"TIMEOUT,secret"`
+		Port    int           `env:"PORT"`
+	}
+
+	err := envtest.NewLoader(map[string]string{"PIN": "hunter2-topsecret"}).Load(&Config{})
+	var pe *env.ParseError
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, "******", pe.Value)
+	require.Equal(t, strconv.ErrSyntax, pe.Err)
+	require.NotContains(t, err.Error(), "hunter2")
+
+	err = envtest.NewLoader(map[string]string{"TIMEOUT": "hunter2-topsecret"}).Load(&Config{})
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, "******", pe.Value)
+	require.NotContains(t, err.Error(), "hunter2")
+
+	// The value of field without option 'secret' is kept.
+	err = envtest.NewLoader(map[string]string{"PORT": "http"}).Load(&Config{})
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, "http", pe.Value)
+}
+
+func BenchmarkEnv_Load_ByEnv(b *testing.B) {
+	envtest.Set(b, specEnvs())
 	l := env.New(env.WithPrefix(prefix))
 
 	b.RunParallel(func(pb *testing.PB) {
@@ -0,0 +1,91 @@
+package env
+
+import (
+	"reflect"
+)
+
+// The merge modes of slice and map, set by tag option 'merge'.
+const (
+	// mergeReplace replaces the existing value, it's the default.
+	mergeReplace = "replace"
+	// mergeAppend appends the elements to slice, and adds the entries with new keys to map.
+	mergeAppend = "append"
+	// mergeMerge appends the elements that not exist to slice, and sets all entries to map.
+	mergeMerge = "merge"
+)
+
+func isMergeMode(mode string) bool {
+	switch mode {
+	case mergeReplace, mergeAppend, mergeMerge:
+		return true
+	}
+	return false
+}
+
+// isMergeable reports whether the type is slice, map, or pointer to them.
+func isMergeable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Map
+}
+
+// mergeField set value to the field by setField and merge with the existing slice or map
+// according to the merge mode. The existing slice and map are not modified.
+func (p *Loader) mergeField(field reflect.Value, value string, tag *tagInfo) error {
+	if tag.merge != mergeAppend && tag.merge != mergeMerge {
+		return p.setField(field, value, tag)
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return p.setField(field, value, tag)
+		}
+		field = field.Elem()
+	}
+	if field.Len() == 0 {
+		return p.setField(field, value, tag)
+	}
+
+	parsed := reflect.New(field.Type()).Elem()
+	if err := p.setField(parsed, value, tag); err != nil {
+		return err
+	}
+
+	switch field.Kind() {
+	case reflect.Slice:
+		result := reflect.MakeSlice(field.Type(), 0, field.Len()+parsed.Len())
+		result = reflect.AppendSlice(result, field)
+		for i := 0; i < parsed.Len(); i++ {
+			elem := parsed.Index(i)
+			if tag.merge == mergeMerge && containsElem(result, elem) {
+				continue
+			}
+			result = reflect.Append(result, elem)
+		}
+		field.Set(result)
+	case reflect.Map:
+		result := reflect.MakeMapWithSize(field.Type(), field.Len()+parsed.Len())
+		iter := field.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), iter.Value())
+		}
+		iter = parsed.MapRange()
+		for iter.Next() {
+			if tag.merge == mergeAppend && result.MapIndex(iter.Key()).IsValid() {
+				continue
+			}
+			result.SetMapIndex(iter.Key(), iter.Value())
+		}
+		field.Set(result)
+	}
+	return nil
+}
+
+func containsElem(slice reflect.Value, elem reflect.Value) bool {
+	for i := 0; i < slice.Len(); i++ {
+		if reflect.DeepEqual(slice.Index(i).Interface(), elem.Interface()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+type MergeConfig struct {
+	Hosts    []string          `env:"HOSTS,merge=append"`
+	Tags     []string          `env:"TAGS,merge=merge"`
+	Ports    *[]int            `env:"PORTS,merge=append"`
+	Replaced []string          `env:"REPLACED,merge=replace"`
+	Labels   map[string]string `env:"LABELS,merge=append"`
+	Settings map[string]string `env:"SETTINGS,merge=merge"`
+}
+
+func TestMerge(t *testing.T) {
+	l := envtest.NewLoader(map[string]string{
+		"HOSTS":    "b c",
+		"TAGS":     "x y",
+		"PORTS":    "81",
+		"REPLACED": "new",
+		"LABELS":   "app:env team:ops",
+		"SETTINGS": "mode:env level:debug",
+	})
+
+	hosts := []string{"a", "b"}
+	ports := []int{80}
+	labels := map[string]string{"app": "code"}
+	cfg := &MergeConfig{
+		Hosts:    hosts,
+		Tags:     []string{"y", "z"},
+		Ports:    &ports,
+		Replaced: []string{"old"},
+		Labels:   labels,
+		Settings: map[string]string{"mode": "code", "size": "1"},
+	}
+	err := l.Load(cfg)
+	require.Nil(t, err, "%+v", err)
+
+	require.Equal(t, []string{"a", "b", "b", "c"}, cfg.Hosts)
+	require.Equal(t, []string{"y", "z", "x"}, cfg.Tags)
+	require.Equal(t, []int{80, 81}, *cfg.Ports)
+	// The non-zero field is not overridden with merge mode 'replace'.
+	require.Equal(t, []string{"old"}, cfg.Replaced)
+	require.Equal(t, map[string]string{"app": "code", "team": "ops"}, cfg.Labels)
+	require.Equal(t, map[string]string{"mode": "env", "size": "1", "level": "debug"}, cfg.Settings)
+
+	// The values supplied in code are not modified.
+	require.Equal(t, []string{"a", "b"}, hosts)
+	require.Equal(t, map[string]string{"app": "code"}, labels)
+
+	// The empty field is set as replace.
+	cfg = &MergeConfig{}
+	err = l.Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, []string{"b", "c"}, cfg.Hosts)
+	require.Equal(t, []int{81}, *cfg.Ports)
+	require.Equal(t, map[string]string{"app": "env", "team": "ops"}, cfg.Labels)
+}
+
+func TestMerge_Invalid(t *testing.T) {
+	type InvalidMode struct {
+		Hosts []string `env:"HOSTS,merge=prepend"`
+	}
+	err := env.New().Load(&InvalidMode{})
+	require.NotNil(t, err)
+
+	type NotSlice struct {
+		Port int `env:"PORT,merge=append"`
+	}
+	err = env.New().Load(&NotSlice{})
+	require.NotNil(t, err)
+	require.Equal(t, "env: assigning 'NotSlice.Port': invalid keyword 'merge' in tag 'env:\"PORT,merge=append\"', the field must be a slice or map", err.Error())
+}
@@ -0,0 +1,74 @@
+package env
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+)
+
+// HostPort represents a network address in the form "host:port",
+// the host may be empty such as ":8080" and the port must be a number in range [0, 65535].
+type HostPort struct {
+	Host string
+	Port uint16
+}
+
+// ParseHostPort parses s as a HostPort.
+func ParseHostPort(s string) (HostPort, error) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return HostPort{}, err
+	}
+	n, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return HostPort{}, fmt.Errorf("invalid port '%s' in address '%s'", port, s)
+	}
+	return HostPort{Host: host, Port: uint16(n)}, nil
+}
+
+// String return the address in the form "host:port".
+func (hp HostPort) String() string {
+	return net.JoinHostPort(hp.Host, strconv.FormatUint(uint64(hp.Port), 10))
+}
+
+// Set implements Setter.
+func (hp *HostPort) Set(value string) error {
+	v, err := ParseHostPort(value)
+	if err != nil {
+		return err
+	}
+	*hp = v
+	return nil
+}
+
+// builtinParsers maintains the parsers of types that not deserialize as expected by its Kind or Setter.
+var builtinParsers = map[reflect.Type]typeParser{
+	reflect.TypeOf(net.IP{}): func(value string) (interface{}, error) {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address '%s'", value)
+		}
+		return ip, nil
+	},
+	reflect.TypeOf(net.IPNet{}): func(value string) (interface{}, error) {
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, err
+		}
+		return *ipNet, nil
+	},
+	reflect.TypeOf(net.HardwareAddr{}): func(value string) (interface{}, error) {
+		return net.ParseMAC(value)
+	},
+	reflect.TypeOf(netip.Addr{}): func(value string) (interface{}, error) {
+		return netip.ParseAddr(value)
+	},
+	reflect.TypeOf(netip.Prefix{}): func(value string) (interface{}, error) {
+		return netip.ParsePrefix(value)
+	},
+	reflect.TypeOf(netip.AddrPort{}): func(value string) (interface{}, error) {
+		return netip.ParseAddrPort(value)
+	},
+}
@@ -0,0 +1,68 @@
+package env_test
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+func TestEnv_Load_Net(t *testing.T) {
+	type Config struct {
+		IP           net.IP           `env:"IP"`
+		IPs          []net.IP         `env:"IPS"`
+		IPNet        net.IPNet        `env:"IPNET"`
+		AllowedCIDRs []*net.IPNet     `env:"ALLOWED_CIDRS"`
+		MAC          net.HardwareAddr `env:"MAC"`
+		Addr         netip.Addr       `env:"ADDR"`
+		Prefixes     []netip.Prefix   `env:"PREFIXES"`
+		AddrPort     *netip.AddrPort  `env:"ADDR_PORT"`
+		Listen       env.HostPort     `env:"LISTEN,default=:8080"`
+		Peers        []env.HostPort   `env:"PEERS"`
+	}
+
+	envs := map[string]string{
+		"IP":            "127.0.0.1",
+		"IPS":           "10.0.0.1 ::1",
+		"IPNET":         "192.168.1.10/24",
+		"ALLOWED_CIDRS": "10.0.0.0/8 fd00::/8",
+		"MAC":           "00:00:5e:00:53:01",
+		"ADDR":          "fe80::1",
+		"PREFIXES":      "10.0.0.0/8 172.16.0.0/12",
+		"ADDR_PORT":     "127.0.0.1:9090",
+		"PEERS":         "a.example.com:80 [::1]:443",
+	}
+
+	cfg := &Config{}
+	err := envtest.NewLoader(envs).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+
+	require.Equal(t, "127.0.0.1", cfg.IP.String())
+	require.Equal(t, "10.0.0.1", cfg.IPs[0].String())
+	require.Equal(t, "::1", cfg.IPs[1].String())
+	require.Equal(t, "192.168.1.0/24", cfg.IPNet.String())
+	require.Equal(t, "10.0.0.0/8", cfg.AllowedCIDRs[0].String())
+	require.Equal(t, "fd00::/8", cfg.AllowedCIDRs[1].String())
+	require.True(t, cfg.AllowedCIDRs[0].Contains(net.ParseIP("10.1.2.3")))
+	require.Equal(t, "00:00:5e:00:53:01", cfg.MAC.String())
+	require.Equal(t, netip.MustParseAddr("fe80::1"), cfg.Addr)
+	require.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("172.16.0.0/12")}, cfg.Prefixes)
+	require.Equal(t, netip.MustParseAddrPort("127.0.0.1:9090"), *cfg.AddrPort)
+	require.Equal(t, env.HostPort{Host: "", Port: 8080}, cfg.Listen)
+	require.Equal(t, []env.HostPort{{Host: "a.example.com", Port: 80}, {Host: "::1", Port: 443}}, cfg.Peers)
+	require.Equal(t, "[::1]:443", cfg.Peers[1].String())
+
+	for key, value := range map[string]string{
+		"IP":        "localhost",
+		"IPNET":     "10.0.0.1",
+		"MAC":       "xx",
+		"ADDR_PORT": "127.0.0.1",
+		"LISTEN":    "127.0.0.1:70000",
+	} {
+		require.NotNil(t, envtest.NewLoader(map[string]string{key: value}).Load(&Config{}), key)
+	}
+}
@@ -1,10 +1,29 @@
 package env
 
+import (
+	"reflect"
+	"time"
+)
+
 type options struct {
 	prefix   string
 	tagName  string
 	override bool
+	unset    bool
+	empty    bool
 	getter   Getter
+	flags    *boundFlags
+	parsers  map[reflect.Type]typeParser
+
+	decryptKey     []byte
+	decryptKeyFile string
+
+	resolvers map[string]Resolver
+
+	profile    string
+	profileKey string
+
+	value tagInfo // the tag options of the value converted by Get
 }
 
 type Option func(opts *options)
@@ -36,3 +55,124 @@ func WithOverride(ok bool) Option {
 		opts.override = ok
 	}
 }
+
+// WithUnsetAfterRead removes the key from the Getter after its value is successfully
+// set to the field as tag option 'unset' for all fields, so that the secrets don't leak
+// to child processes. It takes no effect if the Getter doesn't implement Unsetter.
+func WithUnsetAfterRead(ok bool) Option {
+	return func(opts *options) {
+		opts.unset = ok
+	}
+}
+
+// WithAllowEmpty applies the empty value of the key that is set, as tag option 'allowempty'
+// for all fields: the string is set to "", the slice and map are set to empty, and the
+// default value is not used. By default, the empty value is ignored as the key is not set.
+func WithAllowEmpty(ok bool) Option {
+	return func(opts *options) {
+		opts.empty = ok
+	}
+}
+
+// WithDecryptionKey set the key to decrypt the values encrypted by Encrypt,
+// the values in the form "enc:v1:<base64>" are decrypted before set to fields.
+func WithDecryptionKey(key []byte) Option {
+	return func(opts *options) {
+		opts.decryptKey = key
+	}
+}
+
+// WithDecryptionKeyFile is like WithDecryptionKey but the base64-encoded key is read from file
+// when an encrypted value is loaded, see ReadKeyFile.
+func WithDecryptionKeyFile(path string) Option {
+	return func(opts *options) {
+		opts.decryptKeyFile = path
+	}
+}
+
+// WithResolver register the Resolver for scheme, the values in the form "ref+<scheme>://<ref>"
+// returned by Getter are resolved before set to fields, see FileResolver and EnvResolver.
+// Once a resolver is registered, the reference of the scheme without resolver is an error.
+// The nil Resolver removes the scheme.
+func WithResolver(scheme string, r Resolver) Option {
+	return func(opts *options) {
+		if opts.resolvers == nil {
+			opts.resolvers = make(map[string]Resolver)
+		}
+		if r == nil {
+			delete(opts.resolvers, scheme)
+			return
+		}
+		opts.resolvers[scheme] = r
+	}
+}
+
+// WithProfile selects the profile, the tag option 'default@<profile>' of the profile takes
+// precedence over 'default', e.g. `env:"LOG_LEVEL,default=info,default@dev=debug"`.
+func WithProfile(name string) Option {
+	return func(opts *options) {
+		opts.profile = name
+	}
+}
+
+// WithProfileKey selects the profile by the value of key, e.g. "APP_ENV", it's read from Getter
+// before the fields and not merged with the prefix. It takes no effect if WithProfile is set.
+func WithProfileKey(key string) Option {
+	return func(opts *options) {
+		opts.profileKey = key
+	}
+}
+
+// WithParser register a parser for type T, it takes precedence over the builtin
+// conversions and Setter, and applies to the elements of slice, array and map
+// and to the target of pointer.
+// It's useful for the types that cannot implement Setter, such as *regexp.Regexp.
+func WithParser[T any](parser func(value string) (T, error)) Option {
+	return func(opts *options) {
+		if opts.parsers == nil {
+			opts.parsers = make(map[reflect.Type]typeParser)
+		}
+		opts.parsers[reflect.TypeOf((*T)(nil)).Elem()] = func(value string) (interface{}, error) {
+			return parser(value)
+		}
+	}
+}
+
+// WithSeparator set the separator of slice, array and map elements converted by Get,
+// as tag option 'sep' of struct field, default is space.
+func WithSeparator(sep string) Option {
+	return func(opts *options) {
+		opts.value.sep = sep
+	}
+}
+
+// WithLayout set the layout of time.Time converted by Get, as tag option 'layout'
+// of struct field, e.g. time.RFC1123, "RFC1123" or "unix".
+func WithLayout(layout string) Option {
+	return func(opts *options) {
+		opts.value.layout = lookupTimeLayout(layout)
+	}
+}
+
+// WithLocation set the location of time.Time converted by Get, as tag option 'loc' of struct field.
+func WithLocation(loc *time.Location) Option {
+	return func(opts *options) {
+		opts.value.loc = loc
+	}
+}
+
+// WithUnit set the unit of integer converted by Get, as tag option 'unit' of struct field,
+// only "bytes" is supported.
+func WithUnit(unit string) Option {
+	return func(opts *options) {
+		opts.value.unit = unit
+	}
+}
+
+// WithEncoding set the encoding of []byte and [N]byte converted by Get, as tag option
+// 'encoding' of struct field: raw, base64, base64url or hex.
+func WithEncoding(encoding string) Option {
+	return func(opts *options) {
+		opts.value.encoding = encoding
+	}
+}
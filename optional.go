@@ -0,0 +1,100 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Source describes where the value of Optional comes from.
+type Source int
+
+const (
+	// SourceNone means the value is not set.
+	SourceNone Source = iota
+	// SourceDefault means the value is from tag option 'default'.
+	SourceDefault
+	// SourceGetter means the value is from Getter, e.g. the environment variable.
+	SourceGetter
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceNone:
+		return "none"
+	case SourceDefault:
+		return "default"
+	case SourceGetter:
+		return "getter"
+	}
+	return fmt.Sprintf("Source(%d)", int(s))
+}
+
+// Optional is a value of type T with presence information, it's set by Load as T and
+// records whether the value is from Getter or tag option 'default':
+//
+//	type Config struct {
+//		MaxConns env.Optional[int] `env:"MAX_CONNS,default=10"`
+//	}
+//
+// The zero Optional is not set, and it's not overridden by Load once set unless WithOverride.
+type Optional[T any] struct {
+	value  T
+	source Source
+}
+
+// Value return the value, it's the zero value of T if not set.
+func (o Optional[T]) Value() T {
+	return o.value
+}
+
+// IsSet reports whether the value is from Getter rather than tag option 'default'.
+func (o Optional[T]) IsSet() bool {
+	return o.source == SourceGetter
+}
+
+// Source return where the value comes from.
+func (o Optional[T]) Source() Source {
+	return o.source
+}
+
+// String return the value formatted by fmt.
+func (o Optional[T]) String() string {
+	if o.source == SourceNone {
+		return ""
+	}
+	return fmt.Sprint(o.value)
+}
+
+func (o *Optional[T]) elem() reflect.Value {
+	return reflect.ValueOf(&o.value).Elem()
+}
+
+func (o *Optional[T]) setSource(source Source) {
+	o.source = source
+}
+
+// optional is implemented by *Optional[T].
+type optional interface {
+	// elem return the addressable value
+	elem() reflect.Value
+	setSource(source Source)
+}
+
+var optionalType = reflect.TypeOf((*optional)(nil)).Elem()
+
+// asOptional return the Optional of field if it's an addressable Optional.
+func asOptional(field reflect.Value) (optional, bool) {
+	if !field.CanAddr() || !reflect.PtrTo(field.Type()).Implements(optionalType) {
+		return nil, false
+	}
+	o, ok := field.Addr().Interface().(optional)
+	return o, ok
+}
+
+// optionalElem return the type of value if t is an Optional.
+func optionalElem(t reflect.Type) (reflect.Type, bool) {
+	if !reflect.PtrTo(t).Implements(optionalType) {
+		return nil, false
+	}
+	return reflect.New(t).Interface().(optional).elem().Type(), true
+}
@@ -0,0 +1,95 @@
+package env_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+type OptionalConfig struct {
+	MaxConns env.Optional[int]           `env:"MAX_CONNS,default=10"`
+	Timeout  env.Optional[time.Duration] `env:"TIMEOUT"`
+	Hosts    env.Optional[[]string]      `env:"HOSTS"`
+	Name     env.Optional[*string]       `env:"NAME,allowempty"`
+	Level    env.Optional[string]        `env:"LEVEL"`
+}
+
+func TestOptional(t *testing.T) {
+	envs := map[string]string{"TIMEOUT": "5s", "HOSTS": "a b", "NAME": ""}
+
+	var cfg OptionalConfig
+	err := envtest.NewLoader(envs).Load(&cfg)
+	require.Nil(t, err, "%+v", err)
+
+	require.Equal(t, 10, cfg.MaxConns.Value())
+	require.False(t, cfg.MaxConns.IsSet())
+	require.Equal(t, env.SourceDefault, cfg.MaxConns.Source())
+
+	require.Equal(t, 5*time.Second, cfg.Timeout.Value())
+	require.True(t, cfg.Timeout.IsSet())
+	require.Equal(t, env.SourceGetter, cfg.Timeout.Source())
+
+	require.Equal(t, []string{"a", "b"}, cfg.Hosts.Value())
+	require.True(t, cfg.Hosts.IsSet())
+
+	require.True(t, cfg.Name.IsSet())
+	require.Equal(t, "", *cfg.Name.Value())
+
+	require.Equal(t, "", cfg.Level.Value())
+	require.False(t, cfg.Level.IsSet())
+	require.Equal(t, env.SourceNone, cfg.Level.Source())
+	require.Equal(t, "none", cfg.Level.Source().String())
+
+	// The Optional that is set is not overridden.
+	envs["MAX_CONNS"], envs["TIMEOUT"] = "20", "1s"
+	err = envtest.NewLoader(envs).Load(&cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, 10, cfg.MaxConns.Value())
+	require.Equal(t, 5*time.Second, cfg.Timeout.Value())
+
+	err = envtest.NewLoader(envs, env.WithOverride(true)).Load(&cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, 20, cfg.MaxConns.Value())
+	require.True(t, cfg.MaxConns.IsSet())
+	require.Equal(t, time.Second, cfg.Timeout.Value())
+}
+
+func TestOptional_Error(t *testing.T) {
+	var cfg OptionalConfig
+	err := envtest.NewLoader(map[string]string{"MAX_CONNS": "x"}).Load(&cfg)
+	require.NotNil(t, err)
+	require.Equal(t, "env: assigning 'MAX_CONNS' to 'OptionalConfig.MaxConns': converting 'x' to type 'env.Optional[int]'. details: strconv.ParseInt: parsing \"x\": invalid syntax", err.Error())
+	require.False(t, cfg.MaxConns.IsSet())
+
+	type Unsupported struct {
+		Callback env.Optional[func()] `env:"CALLBACK"`
+	}
+	err = env.New().Load(&Unsupported{})
+	require.NotNil(t, err)
+	require.Equal(t, "env: assigning 'Unsupported.Callback': type 'env.Optional[func()]' is not supported", err.Error())
+}
+
+func TestOptional_Get(t *testing.T) {
+	envtest.Set(t, map[string]string{"PORT": "8080"})
+
+	port, err := env.Get[env.Optional[int]]("PORT")
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, 8080, port.Value())
+	require.True(t, port.IsSet())
+}
+
+func TestOptional_Fields(t *testing.T) {
+	var cfg OptionalConfig
+	l := envtest.NewLoader(map[string]string{"TIMEOUT": "5s"})
+	require.Nil(t, l.Load(&cfg))
+
+	fields, err := l.Fields(&cfg)
+	require.Nil(t, err, "%+v", err)
+	require.Equal(t, "10", fields[0].Value)
+	require.Equal(t, "5s", fields[1].Value)
+	require.Equal(t, "", fields[2].Value)
+}
@@ -0,0 +1,36 @@
+package env
+
+import (
+	"reflect"
+)
+
+// typeParser parses a value to the specified type.
+type typeParser func(value string) (interface{}, error)
+
+// lookupParser return the parser of the specified type, the parser registered by WithParser
+// takes precedence over the builtin parser. Return nil if not found.
+func (p *Loader) lookupParser(refType reflect.Type) typeParser {
+	if parser, ok := p.opts.parsers[refType]; ok {
+		return parser
+	}
+	return builtinParsers[refType]
+}
+
+// parseByType set the value to field with the parser of the field's type,
+// return false if there is no parser for the type.
+func (p *Loader) parseByType(field reflect.Value, value string) (bool, error) {
+	parser := p.lookupParser(field.Type())
+	if parser == nil {
+		return false, nil
+	}
+	v, err := parser(value)
+	if err != nil {
+		return true, err
+	}
+	if v == nil {
+		field.Set(reflect.Zero(field.Type()))
+	} else {
+		field.Set(reflect.ValueOf(v))
+	}
+	return true, nil
+}
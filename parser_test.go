@@ -0,0 +1,89 @@
+package env_test
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+type Level int
+
+func TestEnv_Load_WithParser(t *testing.T) {
+	type Config struct {
+		Pattern  *regexp.Regexp   `env:"PATTERN"`
+		Patterns []*regexp.Regexp `env:"PATTERNS"`
+		Big      *big.Int         `env:"BIG"`
+		Level    Level            `env:"LEVEL"`
+		LevelPtr *Level           `env:"LEVEL_PTR"`
+		Levels   map[string]Level `env:"LEVELS"`
+		Array    [2]Level         `env:"ARRAY"`
+		Custom   CustomList       `env:"CUSTOM"`
+	}
+
+	parseLevel := func(value string) (Level, error) {
+		switch strings.ToLower(value) {
+		case "debug":
+			return 0, nil
+		case "info":
+			return 1, nil
+		case "error":
+			return 2, nil
+		}
+		return 0, errors.New("unknown level")
+	}
+
+	envs := map[string]string{
+		"PATTERN":   "^a+$",
+		"PATTERNS":  "^b ^c",
+		"BIG":       "123456789012345678901234567890",
+		"LEVEL":     "info",
+		"LEVEL_PTR": "error",
+		"LEVELS":    "a:debug b:error",
+		"ARRAY":     "info error",
+		"CUSTOM":    "x",
+	}
+
+	options := []env.Option{
+		env.WithParser(regexp.Compile),
+		env.WithParser(func(value string) (*big.Int, error) {
+			v, ok := new(big.Int).SetString(value, 10)
+			if !ok {
+				return nil, errors.New("invalid big.Int")
+			}
+			return v, nil
+		}),
+		env.WithParser(parseLevel),
+		// Parser takes precedence over Setter.
+		env.WithParser(func(value string) (CustomList, error) {
+			return CustomList{Name: value, Sex: "unknown"}, nil
+		}),
+	}
+
+	cfg := &Config{}
+	err := envtest.NewLoader(envs, options...).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+	require.True(t, cfg.Pattern.MatchString("aaa"))
+	require.Equal(t, 2, len(cfg.Patterns))
+	require.True(t, cfg.Patterns[1].MatchString("cd"))
+	require.Equal(t, "123456789012345678901234567890", cfg.Big.String())
+	require.Equal(t, Level(1), cfg.Level)
+	require.Equal(t, Level(2), *cfg.LevelPtr)
+	require.Equal(t, map[string]Level{"a": 0, "b": 2}, cfg.Levels)
+	require.Equal(t, [2]Level{1, 2}, cfg.Array)
+	require.Equal(t, CustomList{Name: "x", Sex: "unknown"}, cfg.Custom)
+
+	envs["LEVEL"] = "fatal"
+	err = envtest.NewLoader(envs, options...).Load(&Config{})
+	var pe *env.ParseError
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, "LEVEL", pe.KeyName)
+
+	require.NotNil(t, envtest.NewLoader(map[string]string{"PATTERN": "("}, options...).Load(&Config{}))
+}
@@ -0,0 +1,133 @@
+package env
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NewPropertiesGetter return a Getter that get value from a Java .properties document.
+//
+// The separator of key and value can be '=', ':' or white space, a line ends with
+// backslash is continued by the next line, and the escapes such as '\n' and '\uXXXX' are supported.
+// The dot-separated key is merged by Getter.Merge, so "db.host" is mapped to key "DB_HOST".
+func NewPropertiesGetter(data []byte) (Getter, error) {
+	g := newMapGetter(nil)
+	lines, err := readLogicalLines(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		key, value, err := parsePropertiesLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("env: parse properties: %w", err)
+		}
+		g.values[strings.ToUpper(g.mergePath("", key))] = value
+	}
+	return g, nil
+}
+
+// readLogicalLines return the logical lines of properties document, skip the blank and comment lines.
+func readLogicalLines(data []byte) ([]string, error) {
+	var lines []string
+	var logical strings.Builder
+	continued := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+		if !continued && (line == "" || line[0] == '#' || line[0] == '!') {
+			continue
+		}
+
+		// a line is continued if it ends with an odd number of backslash
+		n := len(line) - len(strings.TrimRight(line, "\\"))
+		continued = n%2 == 1
+		if continued {
+			line = line[:len(line)-1]
+		}
+		logical.WriteString(line)
+		if !continued {
+			lines = append(lines, logical.String())
+			logical.Reset()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if logical.Len() != 0 {
+		lines = append(lines, logical.String())
+	}
+	return lines, nil
+}
+
+// parsePropertiesLine split the logical line into unescaped key and value.
+func parsePropertiesLine(line string) (string, string, error) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			end = i
+			break
+		}
+	}
+	key, rest := line[:end], strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	key, err := unescapeProperties(key)
+	if err != nil {
+		return "", "", err
+	}
+	value, err := unescapeProperties(rest)
+	if err != nil {
+		return "", "", err
+	}
+	return key, value, nil
+}
+
+// unescapeProperties replace the escape sequences of properties document.
+func unescapeProperties(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i == len(s)-1 {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("malformed \\uxxxx encoding in '%s'", s)
+			}
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\uxxxx encoding in '%s'", s)
+			}
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
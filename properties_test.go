@@ -0,0 +1,55 @@
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+)
+
+var propertiesDoc = `
+# comment
+! comment
+myapp.address = 127.0.0.1
+myapp.port:8080
+myapp.timeout 30s
+myapp.users = rob \
+              ken \
+              robert
+myapp.colorcodes = red:1 green:2 blue:3
+myapp.embedded.Em2Int=10
+myapp.embedded.Em2Str=你好\tworld
+myapp.servers.0.host = a
+myapp.servers.0.port = 1
+myapp.servers.1.host = b
+myapp.servers.1.port = 2
+key\ with\ spaces = value
+`
+
+func TestEnv_Load_PropertiesGetter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.properties")
+	require.Nil(t, os.WriteFile(path, []byte(propertiesDoc), 0o600))
+	g, err := env.NewFileGetter(path)
+	require.Nil(t, err, "%+v", err)
+
+	cfg := &FileConfig{}
+	err = env.New(env.WithPrefix("MYAPP"), env.WithGetter(g)).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+
+	require.Equal(t, "127.0.0.1", cfg.Address)
+	require.Equal(t, 8080, cfg.Port)
+	require.Equal(t, "30s", cfg.Timeout.String())
+	require.Equal(t, []string{"rob", "ken", "robert"}, cfg.Users)
+	require.Equal(t, map[string]int{"red": 1, "green": 2, "blue": 3}, cfg.ColorCodes)
+	require.Equal(t, 10, cfg.Embedded.Em2Int)
+	require.Equal(t, "你好\tworld", cfg.Embedded.Em2Str)
+	require.Equal(t, FileServer{Host: "a", Port: 1}, cfg.Server0)
+	require.Equal(t, FileServer{Host: "b", Port: 2}, cfg.Server1)
+	require.Equal(t, "value", env.MustGet[string]("key with spaces", env.WithGetter(g)))
+
+	_, err = env.NewPropertiesGetter([]byte(`key = \u12`))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,192 @@
+package env
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// refPrefix is the prefix of secret reference, e.g. "ref+file:///run/secrets/db".
+const refPrefix = "ref+"
+
+// Resolver resolves the secret reference in the form "ref+<scheme>://<ref>" to its value,
+// it's registered per scheme by WithResolver. No resolver is registered by default.
+type Resolver interface {
+	// Resolve return the value of reference, ref is the part after "<scheme>://",
+	// e.g. "/run/secrets/db" of "ref+file:///run/secrets/db".
+	Resolve(ref string) (string, error)
+}
+
+// ResolverFunc is an adapter to allow the use of ordinary functions as Resolver.
+type ResolverFunc func(ref string) (string, error)
+
+func (f ResolverFunc) Resolve(ref string) (string, error) {
+	return f(ref)
+}
+
+// A ResolveError occurs when a secret reference cannot be resolved.
+type ResolveError struct {
+	KeyName string
+	Scheme  string
+	Err     error
+}
+
+func (e *ResolveError) Error() string {
+	return fmt.Sprintf("env: resolving '%s' with scheme '%s': %v", e.KeyName, e.Scheme, e.Err)
+}
+
+func (e *ResolveError) Unwrap() error {
+	return e.Err
+}
+
+// FileResolver resolves the reference to the content of file without trailing newline, or the
+// field of JSON object in file, e.g. "ref+file:///run/secrets/db" or "ref+file:///run/secrets/db.json#pwd".
+// It can read any file that the process can read, register it only if the values are trusted:
+//
+//	env.New(env.WithResolver("file", env.FileResolver))
+var FileResolver Resolver = ResolverFunc(resolveFile)
+
+// EnvResolver resolves the reference to the value of other key get from the Getter of Loader,
+// e.g. "ref+env://OTHER_KEY", the key is not merged with prefix.
+var EnvResolver Resolver = envResolver{}
+
+func resolveFile(ref string) (string, error) {
+	path, field := splitField(ref)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if field != "" {
+		return selectField(data, field)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// envResolver resolves the reference to the value of key get from the Getter of Loader,
+// so that it's isolated from the process environment as the Getter.
+type envResolver struct{}
+
+// Resolve resolves the reference by the process environment, it's used only if
+// the resolver is called without Loader.
+func (envResolver) Resolve(ref string) (string, error) {
+	return resolveKey((&getter{}).Get, ref)
+}
+
+// resolveKey return the value of key by get, the key is not merged with prefix.
+func resolveKey(get func(key string) (string, bool, error), key string) (string, error) {
+	value, found, err := get(key)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("key '%s' is not set", key)
+	}
+	return value, nil
+}
+
+// HTTPResolver resolves the reference by HTTP GET, e.g. with BaseURL "http://127.0.0.1:8200/v1/"
+// the "ref+vault://secret/db#password" is resolved from "http://127.0.0.1:8200/v1/secret/db".
+// The response body is the value, or a JSON object if the reference has a field after '#'.
+type HTTPResolver struct {
+	// BaseURL is prepended to the reference without field.
+	BaseURL string
+	// Header is added to the request, e.g. the token for authentication.
+	Header http.Header
+	// Client is used to send request, default is a client with 10 seconds timeout.
+	Client *http.Client
+}
+
+var defaultResolverClient = &http.Client{Timeout: 10 * time.Second}
+
+func (r *HTTPResolver) Resolve(ref string) (string, error) {
+	path, field := splitField(ref)
+	req, err := http.NewRequest(http.MethodGet, r.BaseURL+path, nil)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+
+	client := r.Client
+	if client == nil {
+		client = defaultResolverClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status '%s' from '%s'", resp.Status, path)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if field != "" {
+		return selectField(data, field)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// splitField split the reference into path and field by '#'.
+func splitField(ref string) (string, string) {
+	if i := strings.LastIndex(ref, "#"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// selectField return the field of JSON object, the field is also looked up in the nested
+// object "data" and "data.data", as the response of Vault KV secrets engine.
+func selectField(data []byte, field string) (string, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return "", fmt.Errorf("expected a JSON object to select field '%s'", field)
+	}
+	for obj != nil {
+		if v, ok := obj[field]; ok {
+			if s, ok := formatScalar(v); ok {
+				return s, nil
+			}
+			return "", fmt.Errorf("field '%s' is not a scalar", field)
+		}
+		obj, _ = obj["data"].(map[string]interface{})
+	}
+	return "", fmt.Errorf("field '%s' is not found", field)
+}
+
+// resolve return the value of secret reference, or the value as is if it's not a reference
+// or no resolver is registered.
+func (p *Loader) resolve(key string, value string) (string, error) {
+	if len(p.opts.resolvers) == 0 || !strings.HasPrefix(value, refPrefix) {
+		return value, nil
+	}
+	i := strings.Index(value, "://")
+	if i < 0 {
+		return value, nil
+	}
+	scheme, ref := value[len(refPrefix):i], value[i+len("://"):]
+
+	r, ok := p.opts.resolvers[scheme]
+	if !ok {
+		return "", &ResolveError{KeyName: key, Scheme: scheme, Err: errors.New("no resolver is registered")}
+	}
+	var resolved string
+	var err error
+	if _, ok := r.(envResolver); ok {
+		resolved, err = resolveKey(p.lookup, ref)
+	} else {
+		resolved, err = r.Resolve(ref)
+	}
+	if err != nil {
+		return "", &ResolveError{KeyName: key, Scheme: scheme, Err: err}
+	}
+	return resolved, nil
+}
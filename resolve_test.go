@@ -0,0 +1,185 @@
+package env_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+type ResolveConfig struct {
+	Password string `env:"PASSWORD,secret"`
+	Port     int    `env:"PORT,default=8080"`
+	Host     string `env:"HOST"`
+}
+
+func TestResolver_File(t *testing.T) {
+	dir := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "password"), []byte("s3cr3t\n"), 0600))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"port": 5432, "host": "db"}`), 0600))
+
+	envtest.Set(t, map[string]string{
+		"PASSWORD": "ref+file://" + filepath.Join(dir, "password"),
+		"PORT":     "ref+file://" + filepath.Join(dir, "db.json") + "#port",
+		"HOST":     "ref+file://" + filepath.Join(dir, "db.json") + "#host",
+	})
+
+	// The references are not resolved by default.
+	v, err := env.Get[string]("PASSWORD")
+	require.Nil(t, err)
+	require.Equal(t, "ref+file://"+filepath.Join(dir, "password"), v)
+
+	file := env.WithResolver("file", env.FileResolver)
+	cfg := &ResolveConfig{}
+	require.Nil(t, env.New(file).Load(cfg))
+	require.Equal(t, &ResolveConfig{Password: "s3cr3t", Port: 5432, Host: "db"}, cfg)
+
+	v, err = env.Get[string]("PASSWORD", file)
+	require.Nil(t, err)
+	require.Equal(t, "s3cr3t", v)
+
+	envtest.Set(t, map[string]string{"PASSWORD": "ref+file://" + filepath.Join(dir, "missing")})
+	err = env.New(file).Load(&ResolveConfig{})
+	var re *env.ResolveError
+	require.True(t, errors.As(err, &re))
+	require.Equal(t, "PASSWORD", re.KeyName)
+	require.Equal(t, "file", re.Scheme)
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	envtest.Set(t, map[string]string{
+		"PASSWORD": "ref+file://" + filepath.Join(dir, "password"),
+		"HOST":     "ref+file://" + filepath.Join(dir, "db.json") + "#user",
+	})
+	err = env.New(file).Load(&ResolveConfig{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "field 'user' is not found")
+}
+
+func TestResolver_ParseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "port")
+	require.Nil(t, os.WriteFile(path, []byte("topsecret"), 0600))
+	envs := map[string]string{"PORT": "ref+file://" + path}
+	file := env.WithResolver("file", env.FileResolver)
+
+	// The resolved value is reported as the value of secret field.
+	err := envtest.NewLoader(envs, file).Load(&ResolveConfig{})
+	require.NotNil(t, err)
+	require.Equal(t, "env: assigning 'PORT' to 'ResolveConfig.Port': converting '******' to type 'int'. details: invalid syntax", err.Error())
+
+	envtest.Set(t, envs)
+	_, err = env.Get[int]("PORT", file)
+	require.NotNil(t, err)
+	require.NotContains(t, err.Error(), "topsecret")
+}
+
+func TestResolver_Env(t *testing.T) {
+	resolver := env.WithResolver("env", env.EnvResolver)
+	envtest.Set(t, map[string]string{"PASSWORD": "ref+env://DB_PASSWORD", "DB_PASSWORD": "s3cr3t"})
+	cfg := &ResolveConfig{}
+	require.Nil(t, env.New(resolver).Load(cfg))
+	require.Equal(t, "s3cr3t", cfg.Password)
+
+	envtest.Set(t, map[string]string{"PASSWORD": "ref+env://NOT_SET_PASSWORD"})
+	err := env.New(resolver).Load(&ResolveConfig{})
+	var re *env.ResolveError
+	require.True(t, errors.As(err, &re))
+	require.Equal(t, "env", re.Scheme)
+
+	// The key is get from the Getter of Loader rather than the process environment.
+	envtest.Set(t, map[string]string{"DB_HOST": "process"})
+	cfg = &ResolveConfig{}
+	l := envtest.NewLoader(map[string]string{"HOST": "ref+env://DB_HOST", "DB_HOST": "isolated"}, resolver)
+	require.Nil(t, l.Load(cfg))
+	require.Equal(t, "isolated", cfg.Host)
+
+	err = envtest.NewLoader(map[string]string{"HOST": "ref+env://HOME"}, resolver).Load(&ResolveConfig{})
+	require.True(t, errors.As(err, &re))
+	require.Contains(t, err.Error(), "key 'HOME' is not set")
+}
+
+func TestResolver_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/db":
+			_, _ = w.Write([]byte(`{"data": {"data": {"password": "s3cr3t", "port": 5432}}}`))
+		case "/v1/raw/host":
+			_, _ = w.Write([]byte("db.local\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := &env.HTTPResolver{
+		BaseURL: server.URL + "/v1/",
+		Header:  http.Header{"X-Vault-Token": []string{"token"}},
+		Client:  server.Client(),
+	}
+	g := env.MapGetter(map[string]string{
+		"PASSWORD": "ref+vault://secret/data/db#password",
+		"PORT":     "ref+vault://secret/data/db#port",
+		"HOST":     "ref+vault://raw/host",
+	})
+
+	cfg := &ResolveConfig{}
+	require.Nil(t, env.New(env.WithGetter(g), env.WithResolver("vault", resolver)).Load(cfg))
+	require.Equal(t, &ResolveConfig{Password: "s3cr3t", Port: 5432, Host: "db.local"}, cfg)
+
+	g = env.MapGetter(map[string]string{"PASSWORD": "ref+vault://secret/data/other#password"})
+	err := env.New(env.WithGetter(g), env.WithResolver("vault", resolver)).Load(&ResolveConfig{})
+	var re *env.ResolveError
+	require.True(t, errors.As(err, &re))
+	require.Contains(t, err.Error(), "404")
+
+	resolver.Header = nil
+	g = env.MapGetter(map[string]string{"PASSWORD": "ref+vault://secret/data/db#password"})
+	err = env.New(env.WithGetter(g), env.WithResolver("vault", resolver)).Load(&ResolveConfig{})
+	require.True(t, errors.As(err, &re))
+	require.Contains(t, err.Error(), "403")
+}
+
+func TestResolver_Custom(t *testing.T) {
+	exec := env.ResolverFunc(func(ref string) (string, error) {
+		if ref == "pass show db" {
+			return "s3cr3t", nil
+		}
+		return "", errors.New("unknown command")
+	})
+	g := env.MapGetter(map[string]string{"PASSWORD": "ref+exec://pass show db"})
+
+	cfg := &ResolveConfig{}
+	require.Nil(t, env.New(env.WithGetter(g), env.WithResolver("exec", exec)).Load(cfg))
+	require.Equal(t, "s3cr3t", cfg.Password)
+
+	// The scheme is not registered while other scheme is.
+	err := env.New(env.WithGetter(g), env.WithResolver("env", env.EnvResolver)).Load(&ResolveConfig{})
+	var re *env.ResolveError
+	require.True(t, errors.As(err, &re))
+	require.Equal(t, "exec", re.Scheme)
+	require.True(t, strings.Contains(err.Error(), "no resolver is registered"))
+
+	// The nil Resolver removes the scheme, and the value is kept as is without resolver.
+	cfg = &ResolveConfig{}
+	require.Nil(t, env.New(env.WithGetter(g), env.WithResolver("exec", exec), env.WithResolver("exec", nil)).Load(cfg))
+	require.Equal(t, "ref+exec://pass show db", cfg.Password)
+}
+
+func TestResolver_NotReference(t *testing.T) {
+	// The default value and the values without scheme are not resolved.
+	g := env.MapGetter(map[string]string{"PASSWORD": "ref+plain", "HOST": "ref+env:HOME"})
+	cfg := &ResolveConfig{}
+	require.Nil(t, env.New(env.WithGetter(g), env.WithResolver("env", env.EnvResolver)).Load(cfg))
+	require.Equal(t, &ResolveConfig{Password: "ref+plain", Port: 8080, Host: "ref+env:HOME"}, cfg)
+}
@@ -17,6 +17,18 @@ func (f SetterFunc) Set(value string) error {
 	return f(value)
 }
 
+var (
+	setterType            = reflect.TypeOf((*Setter)(nil)).Elem()
+	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+// hasSetters reports whether the addressable value of type has setters.
+func hasSetters(t reflect.Type) bool {
+	pt := reflect.PtrTo(t)
+	return pt.Implements(setterType) || pt.Implements(textUnmarshalerType) || pt.Implements(binaryUnmarshalerType)
+}
+
 // getSetters return all eligible setter instances
 func getSetters(field reflect.Value) []Setter {
 	var setters []Setter
@@ -0,0 +1,86 @@
+package env
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Special layouts that parse the value as an integer unix timestamp.
+const (
+	layoutUnix      = "unix"
+	layoutUnixMilli = "unixmilli"
+	layoutUnixMicro = "unixmicro"
+	layoutUnixNano  = "unixnano"
+)
+
+// timeLayouts maps the layout name that can be used in tag option 'layout' to the layout.
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    "2006-01-02 15:04:05",
+	"DateOnly":    "2006-01-02",
+	"TimeOnly":    "15:04:05",
+}
+
+// lookupTimeLayout return the layout by name, the name is returned as custom layout if not found.
+func lookupTimeLayout(name string) string {
+	if layout, ok := timeLayouts[name]; ok {
+		return layout
+	}
+	return name
+}
+
+// parseTime parses value to time.Time by the layout and location specified in tag.
+func parseTime(value string, tag *tagInfo) (time.Time, error) {
+	var t time.Time
+	switch tag.layout {
+	case layoutUnix, layoutUnixMilli, layoutUnixMicro, layoutUnixNano:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return t, err
+		}
+		switch tag.layout {
+		case layoutUnix:
+			t = time.Unix(n, 0)
+		case layoutUnixMilli:
+			t = time.UnixMilli(n)
+		case layoutUnixMicro:
+			t = time.UnixMicro(n)
+		default:
+			t = time.Unix(0, n)
+		}
+	default:
+		layout := tag.layout
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+		loc := tag.loc
+		if loc == nil {
+			loc = time.UTC
+		}
+		var err error
+		if t, err = time.ParseInLocation(layout, value, loc); err != nil {
+			return t, err
+		}
+	}
+	if tag.loc != nil {
+		t = t.In(tag.loc)
+	}
+	return t, nil
+}
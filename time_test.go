@@ -0,0 +1,124 @@
+package env_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu31/env"
+	"github.com/yu31/env/envtest"
+)
+
+func TestEnv_Load_Time(t *testing.T) {
+	type Config struct {
+		Default   time.Time            `env:"DEFAULT"`
+		RFC1123   time.Time            `env:"RFC1123,layout=RFC1123"`
+		DateOnly  *time.Time           `env:"DATE_ONLY,layout=DateOnly,loc=Asia/Shanghai"`
+		Custom    time.Time            `env:"CUSTOM,layout=2006/01/02 15:04"`
+		Unix      time.Time            `env:"UNIX,layout=unix,loc=UTC"`
+		UnixMilli time.Time            `env:"UNIX_MILLI,layout=unixmilli,loc=UTC"`
+		Loc       time.Time            `env:"LOC,loc=UTC"`
+		Slice     []time.Time          `env:"SLICE,layout=DateOnly"`
+		Map       map[string]time.Time `env:"MAP,layout=unix,loc=UTC"`
+	}
+
+	envs := map[string]string{
+		"DEFAULT":    "2020-11-18T15:09:42+08:00",
+		"RFC1123":    "Wed, 18 Nov 2020 15:09:42 UTC",
+		"DATE_ONLY":  "2020-11-18",
+		"CUSTOM":     "2020/11/18 15:09",
+		"UNIX":       "1605683382",
+		"UNIX_MILLI": "1605683382123",
+		"LOC":        "2020-11-18T15:09:42+08:00",
+		"SLICE":      "2020-11-18 2020-11-19",
+		"MAP":        "a:1605683382 b:0",
+	}
+
+	cfg := &Config{}
+	err := envtest.NewLoader(envs).Load(cfg)
+	require.Nil(t, err, "%+v", err)
+
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.Nil(t, err)
+
+	require.True(t, time.Date(2020, 11, 18, 7, 9, 42, 0, time.UTC).Equal(cfg.Default))
+	require.True(t, time.Date(2020, 11, 18, 15, 9, 42, 0, time.UTC).Equal(cfg.RFC1123))
+	require.Equal(t, time.Date(2020, 11, 18, 0, 0, 0, 0, shanghai), *cfg.DateOnly)
+	require.Equal(t, time.Date(2020, 11, 18, 15, 9, 0, 0, time.UTC), cfg.Custom)
+	require.Equal(t, time.Date(2020, 11, 18, 7, 9, 42, 0, time.UTC), cfg.Unix)
+	require.Equal(t, time.Date(2020, 11, 18, 7, 9, 42, 123000000, time.UTC), cfg.UnixMilli)
+	require.Equal(t, time.Date(2020, 11, 18, 7, 9, 42, 0, time.UTC), cfg.Loc)
+	require.Equal(t, []time.Time{
+		time.Date(2020, 11, 18, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 11, 19, 0, 0, 0, 0, time.UTC),
+	}, cfg.Slice)
+	require.Equal(t, map[string]time.Time{
+		"a": time.Date(2020, 11, 18, 7, 9, 42, 0, time.UTC),
+		"b": time.Unix(0, 0).UTC(),
+	}, cfg.Map)
+
+	envs["UNIX"] = "2020-11-18"
+	require.NotNil(t, envtest.NewLoader(envs).Load(&Config{}))
+
+	type BadLoc struct {
+		T time.Time `env:"T,loc=Nowhere/City"`
+	}
+	require.NotNil(t, env.New().Load(&BadLoc{}))
+}
+
+func TestEnv_Load_Time_Separator(t *testing.T) {
+	type Config struct {
+		DateTimes []time.Time            `env:"DATE_TIMES,layout=DateTime,sep=;"`
+		RFC1123   map[string]time.Time   `env:"RFC1123,layout=RFC1123,sep=|"`
+		Ptr       *[2]time.Time          `env:"PTR,layout=2006/01/02 15:04,sep=;"`
+		Optional  env.Optional[[]string] `env:"OPTIONAL,sep=;"`
+		Strings   []string               `env:"STRINGS,sep=;"`
+	}
+
+	l := envtest.NewLoader(map[string]string{
+		"DATE_TIMES": "2020-01-01 10:00:00;2020-01-02 11:30:00",
+		"RFC1123":    "a:Wed, 18 Nov 2020 15:09:42 UTC|b:Thu, 19 Nov 2020 15:09:42 UTC",
+		"PTR":        "2020/01/01 10:00;2020/01/02 11:30",
+		"STRINGS":    "a b;c",
+		"OPTIONAL":   "x;y",
+	})
+	cfg := &Config{}
+	err := l.Load(cfg)
+	require.Nil(t, err, "%+v", err)
+
+	require.Equal(t, []time.Time{
+		time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 2, 11, 30, 0, 0, time.UTC),
+	}, cfg.DateTimes)
+	require.Len(t, cfg.RFC1123, 2)
+	require.True(t, time.Date(2020, 11, 18, 15, 9, 42, 0, time.UTC).Equal(cfg.RFC1123["a"]))
+	require.True(t, time.Date(2020, 11, 19, 15, 9, 42, 0, time.UTC).Equal(cfg.RFC1123["b"]))
+	require.Equal(t, &[2]time.Time{
+		time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 2, 11, 30, 0, 0, time.UTC),
+	}, cfg.Ptr)
+	require.Equal(t, []string{"a b", "c"}, cfg.Strings)
+	require.Equal(t, []string{"x", "y"}, cfg.Optional.Value())
+
+	// The fields are formatted with the separator.
+	fields, err := env.New().Fields(cfg)
+	require.Nil(t, err)
+	require.Equal(t, "2020-01-01 10:00:00;2020-01-02 11:30:00", fields[0].Value)
+	require.Equal(t, "a b;c", fields[4].Value)
+
+	// The layout contains space cannot be used for elements separated by space.
+	type Space struct {
+		DateTimes []time.Time `env:"DATE_TIMES,layout=DateTime"`
+	}
+	err = l.Load(&Space{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "the layout contains space")
+
+	type NotCollection struct {
+		Time time.Time `env:"TIME,sep=;"`
+	}
+	err = l.Load(&NotCollection{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "invalid keyword 'sep'")
+}